@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github-octokit-poc/internal/alerts"
+	"github-octokit-poc/internal/config"
+	"github-octokit-poc/internal/snapshot"
+	"github-octokit-poc/utils"
+)
+
+// newAlertEngine monta as regras e os notificadores a partir da configuração.
+// Sem ALERT_RULES_FILE são usadas as regras padrão; sem notificadores, os
+// alertas disparados aparecem apenas no log.
+func newAlertEngine(cfg *config.Config, analyzer utils.Analyzer) (*alerts.Engine, error) {
+	rules := alerts.DefaultRules
+	if cfg.AlertRulesFile != "" {
+		loaded, err := alerts.LoadRules(cfg.AlertRulesFile)
+		if err != nil {
+			return nil, err
+		}
+		rules = loaded
+	}
+
+	var notifiers []alerts.Notifier
+	if cfg.AlertWebhookURL != "" {
+		notifiers = append(notifiers, alerts.NewWebhookNotifier(cfg.AlertWebhookURL))
+	}
+	if cfg.AlertSlackURL != "" {
+		notifiers = append(notifiers, alerts.NewSlackNotifier(cfg.AlertSlackURL))
+	}
+	if cfg.AlertSMTPAddr != "" {
+		recipients := splitList(cfg.AlertSMTPTo)
+		if cfg.AlertSMTPFrom == "" || len(recipients) == 0 {
+			return nil, fmt.Errorf("ALERT_SMTP_ADDR exige ALERT_SMTP_FROM e ALERT_SMTP_TO")
+		}
+		notifiers = append(notifiers, &alerts.EmailNotifier{
+			Addr:     cfg.AlertSMTPAddr,
+			From:     cfg.AlertSMTPFrom,
+			To:       recipients,
+			Username: cfg.AlertSMTPUsername,
+			Password: cfg.AlertSMTPPassword,
+		})
+	}
+
+	return alerts.NewEngine(rules, analyzer, notifiers...), nil
+}
+
+// warnMissingSnapshots avisa quando as regras de variação não têm com o que
+// comparar: apenas o daemon salva snapshots, e sem nenhum no store essas
+// regras nunca disparam nas execuções de analyze e --batch
+func warnMissingSnapshots(engine *alerts.Engine, store *snapshot.Store) {
+	names := engine.ChangeRules()
+	if len(names) == 0 || !store.Empty() {
+		return
+	}
+	log.Printf("⚠️ Regras de variação (%s) não serão avaliadas: nenhum snapshot em %s. Os snapshots são salvos pelo modo daemon",
+		strings.Join(names, ", "), store.Dir())
+}
+
+// splitList separa valores por vírgula, ignorando itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cache"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/output"
+	"github-octokit-poc/utils"
+)
+
+// batchResult representa o resultado da análise de um repositório do lote
+type batchResult struct {
+	Target   cli.Target
+	Data     *extractor.RepositoryData
+	Err      error
+	Cached   bool
+	Duration time.Duration
+}
+
+// runBatch executa o pipeline completo para cada repositório listado no
+// arquivo, compartilhando cliente e cache e continuando após falhas
+func runBatch(client *github.Client, batchFile string, opts runOptions) error {
+	targets, err := cli.LoadTargetsFile(batchFile)
+	if err != nil {
+		return err
+	}
+	log.Printf("📚 Execução em lote: %d repositórios em %s", len(targets), batchFile)
+
+	timestamp := output.NewTimestamp()
+	repoCache := cache.New(0)
+	results := make([]*batchResult, 0, len(targets))
+
+	for i, target := range targets {
+		log.Printf("\n📦 [%d/%d] %s", i+1, len(targets), target.FullName())
+		results = append(results, analyzeBatchTarget(client, repoCache, target, opts, timestamp))
+	}
+
+	summary := formatBatchSummary(results, opts.Analyzer.HealthModel)
+	fmt.Println("\n" + summary)
+
+	path, err := output.SaveSummary(opts.OutputDir, timestamp, "batch_summary.txt", summary)
+	if err != nil {
+		log.Printf("⚠️ Erro ao salvar resumo do lote: %v", err)
+	} else {
+		log.Printf("📋 Resumo do lote: %s", path)
+	}
+
+	failed := countBatchFailures(results)
+	if failed == len(results) {
+		return fmt.Errorf("todos os %d repositórios do lote falharam", failed)
+	}
+	if failed > 0 {
+		log.Printf("⚠️ %d de %d repositórios falharam", failed, len(results))
+	}
+
+	return nil
+}
+
+// analyzeBatchTarget analisa um repositório do lote, reaproveitando o cache
+// quando o mesmo repositório aparece mais de uma vez na lista
+func analyzeBatchTarget(client *github.Client, repoCache *cache.Cache, target cli.Target, opts runOptions, timestamp string) *batchResult {
+	start := time.Now()
+	result := &batchResult{Target: target}
+
+	if data, ok := repoCache.Get(target.Owner, target.Repo); ok {
+		log.Printf("♻️ %s já analisado neste lote, reaproveitando dados", target.FullName())
+		result.Data = data
+		result.Cached = true
+		return result
+	}
+
+	handler := opts.newHandler(target.Owner, target.Repo, timestamp)
+	data, err := runPipeline(client, target.Owner, target.Repo, handler, opts, false)
+	result.Duration = time.Since(start)
+	if err != nil {
+		log.Printf("❌ Falha ao analisar %s: %v", target.FullName(), err)
+		result.Err = err
+		return result
+	}
+
+	repoCache.Set(target.Owner, target.Repo, data)
+	result.Data = data
+	return result
+}
+
+// formatBatchSummary monta a tabela de resumo do lote, com a saúde calculada
+// pelo modelo configurado
+func formatBatchSummary(results []*batchResult, model *utils.HealthModel) string {
+	var sb strings.Builder
+
+	sb.WriteString("📚 RESUMO DO LOTE\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPOSITÓRIO\tSTATUS\tSTARS\tFORKS\tISSUES\tSAÚDE\tDURAÇÃO\tDETALHES")
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(tw, "%s\t❌ falha\t-\t-\t-\t-\t%s\t%s\n",
+				result.Target.FullName(),
+				result.Duration.Round(time.Millisecond),
+				firstLine(result.Err.Error()))
+			continue
+		}
+
+		status := "✅ ok"
+		if result.Cached {
+			status = "♻️ duplicado"
+		}
+		health := utils.AnalyzeHealth(result.Data, model)
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%.0f (%s)\t%s\t\n",
+			result.Target.FullName(),
+			status,
+			result.Data.Statistics.Stars,
+			result.Data.Statistics.Forks,
+			result.Data.Statistics.Issues,
+			health.HealthScore,
+			health.MaintenanceStatus,
+			result.Duration.Round(time.Millisecond))
+	}
+	tw.Flush()
+
+	failed := countBatchFailures(results)
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString(fmt.Sprintf("Total: %d | Sucesso: %d | Falhas: %d\n", len(results), len(results)-failed, failed))
+
+	return sb.String()
+}
+
+// countBatchFailures conta quantos repositórios do lote falharam
+func countBatchFailures(results []*batchResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// firstLine retorna apenas a primeira linha de uma mensagem
+func firstLine(message string) string {
+	if idx := strings.Index(message, "\n"); idx >= 0 {
+		return message[:idx]
+	}
+	return message
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/output"
+	"github-octokit-poc/utils"
+)
+
+// runChangelog gera o changelog entre duas refs; refs não informadas são
+// escolhidas a partir dos releases do repositório
+func runChangelog(client *github.Client, target cli.Target, from, to, outputDir string) error {
+	log.Printf("📋 Gerando changelog de %s", target.FullName())
+
+	if from == "" || to == "" {
+		releases, err := extractor.ListReleases(client, target.Owner, target.Repo)
+		if err != nil {
+			return err
+		}
+		from, to, err = utils.ChangelogRange(releases, from, to)
+		if err != nil {
+			return err
+		}
+		log.Printf("🏷️ Refs escolhidas pelos releases: %s...%s", from, to)
+	}
+
+	data, err := extractor.ExtractChangelog(client, target.Owner, target.Repo, from, to)
+	if err != nil {
+		return err
+	}
+
+	content := utils.BuildChangelog(data).RenderMarkdown()
+	fmt.Println("\n" + content)
+
+	path, err := output.SaveSummary(outputDir, output.NewTimestamp(), "changelog.md", content)
+	if err != nil {
+		return fmt.Errorf("erro ao salvar changelog: %v", err)
+	}
+	log.Printf("💾 Changelog salvo em: %s", path)
+	return nil
+}
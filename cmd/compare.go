@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cache"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/output"
+	"github-octokit-poc/utils"
+)
+
+// runCompare extrai cada repositório e gera a matriz de comparação no
+// terminal, em Markdown e em HTML
+func runCompare(client *github.Client, targets []cli.Target, outputDir string, analyzer utils.Analyzer) error {
+	log.Printf("⚖️ Comparando %d repositórios", len(targets))
+
+	repoCache := cache.New(0)
+	var datasets []*extractor.RepositoryData
+
+	for i, target := range targets {
+		log.Printf("\n📦 [%d/%d] %s", i+1, len(targets), target.FullName())
+
+		if _, ok := repoCache.Get(target.Owner, target.Repo); ok {
+			log.Printf("♻️ %s repetido na comparação, ignorando", target.FullName())
+			continue
+		}
+
+		data, err := extractor.ExtractRepositoryData(client, target.Owner, target.Repo)
+		if err != nil {
+			log.Printf("❌ Falha ao extrair %s: %v", target.FullName(), err)
+			continue
+		}
+
+		repoCache.Set(target.Owner, target.Repo, data)
+		datasets = append(datasets, data)
+	}
+
+	if len(datasets) < 2 {
+		return fmt.Errorf("comparação precisa de pelo menos 2 repositórios extraídos com sucesso (obtidos: %d)", len(datasets))
+	}
+
+	comparison := utils.CompareRepositories(datasets, analyzer)
+	fmt.Println("\n" + comparison.RenderTable())
+
+	timestamp := output.NewTimestamp()
+	files := map[string]string{
+		"comparison.md":   comparison.RenderMarkdown(),
+		"comparison.html": comparison.RenderHTML(),
+	}
+	for _, name := range []string{"comparison.md", "comparison.html"} {
+		path, err := output.SaveSummary(outputDir, timestamp, name, files[name])
+		if err != nil {
+			log.Printf("⚠️ Erro ao salvar %s: %v", name, err)
+			continue
+		}
+		log.Printf("💾 Comparação salva em: %s", path)
+	}
+
+	return nil
+}
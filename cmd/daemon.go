@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/config"
+	"github-octokit-poc/internal/scheduler"
+)
+
+// runDaemon executa a extração agendada dos repositórios até receber SIGINT
+// ou SIGTERM, salvando snapshots e o estado das execuções
+func runDaemon(client *github.Client, cfg *config.Config, args *cli.Args, opts runOptions) error {
+	targets, err := resolveTargets(cfg, args)
+	if err != nil {
+		return err
+	}
+
+	expr := cfg.DaemonSchedule
+	if args.Schedule != "" {
+		expr = args.Schedule
+	}
+	schedule, err := scheduler.Parse(expr)
+	if err != nil {
+		return err
+	}
+
+	addr := cfg.DaemonAddr
+	if args.Addr != "" {
+		addr = args.Addr
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	store := opts.Snapshots
+	budget := scheduler.NewBudget(client, cfg.RateLimitReserve)
+	daemon := scheduler.New(client, schedule, budget, store, targets, cfg.DaemonStatusFile)
+	daemon.AfterExtract(func(data *extractor.RepositoryData) {
+		analysis, previous := opts.analyze(data)
+		if _, err := opts.Alerts.Process(data, analysis, previous); err != nil {
+			log.Printf("⚠️ Erro ao enviar alertas: %v", err)
+		}
+	})
+
+	log.Printf("🗓️ Daemon iniciado: %d repositórios, agenda %q, snapshots em %s", len(targets), schedule, store.Dir())
+	log.Printf("   Reserva de rate limit: %d requisições · status em %s", cfg.RateLimitReserve, valueOrNone(cfg.DaemonStatusFile))
+
+	run := func() {
+		if args.RunNow {
+			daemon.RunOnce(ctx)
+		}
+		daemon.Run(ctx)
+	}
+
+	if addr == "" {
+		run()
+		return nil
+	}
+
+	// Com endereço configurado, o estado também fica disponível via HTTP
+	go run()
+
+	mux := http.NewServeMux()
+	mux.Handle("/status", daemon)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "GitHub Repository Analyzer - estado do daemon em /status")
+	})
+
+	log.Printf("🌐 Status do daemon em %s/status", addr)
+	return serveUntilDone(ctx, &http.Server{Addr: addr, Handler: mux})
+}
+
+// valueOrNone descreve valores opcionais vazios nos logs
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(desativado)"
+	}
+	return value
+}
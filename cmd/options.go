@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"log"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/internal/alerts"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/config"
+	"github-octokit-poc/internal/output"
+	"github-octokit-poc/internal/snapshot"
+	"github-octokit-poc/utils"
+)
+
+// runOptions agrupa as opções de saída compartilhadas pelos modos de execução
+type runOptions struct {
+	OutputDir string
+	Formats   []string
+	// TextfileDir publica o .prom no diretório do textfile collector
+	TextfileDir string
+	Alerts      *alerts.Engine
+	// Analyzer aplica o modelo de saúde configurado em todos os modos
+	Analyzer utils.Analyzer
+	// Snapshots fornece os snapshots anteriores para alertas e tendências
+	Snapshots *snapshot.Store
+}
+
+// newRunOptions combina configuração e argumentos; a linha de comando tem
+// prioridade sobre as variáveis de ambiente
+func newRunOptions(cfg *config.Config, args *cli.Args) (runOptions, error) {
+	opts := runOptions{OutputDir: cfg.OutputDir}
+	if args.OutputDir != "" {
+		opts.OutputDir = args.OutputDir
+	}
+
+	formats := cfg.OutputFormats
+	if args.Formats != "" {
+		formats = args.Formats
+	}
+	parsed, err := output.ParseFormats(formats)
+	if err != nil {
+		return opts, err
+	}
+	if args.HTML && !containsFormat(parsed, output.FormatHTML) {
+		parsed = append(parsed, output.FormatHTML)
+	}
+
+	// Com o textfile collector configurado, toda execução publica o .prom
+	if cfg.TextfileDir != "" {
+		opts.TextfileDir = cfg.TextfileDir
+		if !containsFormat(parsed, output.FormatProm) {
+			parsed = append(parsed, output.FormatProm)
+		}
+	}
+	opts.Formats = parsed
+
+	// Modelo de saúde customizado vale para todos os modos de execução
+	if cfg.HealthModelFile != "" {
+		model, err := utils.LoadHealthModel(cfg.HealthModelFile)
+		if err != nil {
+			return opts, err
+		}
+		opts.Analyzer.HealthModel = model
+	}
+
+	// Snapshots do daemon servem de base para alertas de variação e para a
+	// tendência de downloads
+	opts.Snapshots = snapshot.NewStore(cfg.SnapshotDir)
+
+	opts.Alerts, err = newAlertEngine(cfg, opts.Analyzer)
+	if err != nil {
+		return opts, err
+	}
+	if args.Command == cli.CommandAnalyze {
+		warnMissingSnapshots(opts.Alerts, opts.Snapshots)
+	}
+
+	return opts, nil
+}
+
+// newHandler cria o handler de output de um repositório conforme as opções
+func (o runOptions) newHandler(owner, repo, timestamp string) *output.Handler {
+	return output.NewHandlerForRun(owner, repo, o.OutputDir, timestamp).
+		SetFormats(o.Formats).
+		SetTextfileDir(o.TextfileDir)
+}
+
+// analyze carrega uma única vez os snapshots anteriores do repositório e
+// executa os analisadores. Retorna também o snapshot anterior, usado pelas
+// regras de variação dos alertas.
+func (o runOptions) analyze(data *extractor.RepositoryData) (*utils.Analysis, *extractor.RepositoryData) {
+	previous, baseline := o.loadHistory(data)
+	return o.Analyzer.AnalyzeWithBaseline(data, baseline), previous
+}
+
+// loadHistory retorna o snapshot mais recente antes da extração e a base da
+// tendência de downloads: o mais recente com pelo menos
+// utils.DownloadTrendWindow de distância ou, sem ele, o anterior
+func (o runOptions) loadHistory(data *extractor.RepositoryData) (previous, baseline *extractor.RepositoryData) {
+	meta := data.ExtractionMeta
+	if o.Snapshots == nil || meta == nil || meta.ExtractedAt.IsZero() {
+		return nil, nil
+	}
+
+	previous, err := o.Snapshots.Previous(meta.Owner, meta.Repo, meta.ExtractedAt)
+	if err != nil {
+		log.Printf("⚠️ Erro ao carregar snapshot anterior: %v", err)
+		return nil, nil
+	}
+	if previous == nil {
+		return nil, nil
+	}
+
+	baseline, err = o.Snapshots.Previous(meta.Owner, meta.Repo, meta.ExtractedAt.Add(-utils.DownloadTrendWindow))
+	if err != nil {
+		log.Printf("⚠️ Erro ao carregar snapshot para a tendência de downloads: %v", err)
+	}
+	if baseline == nil {
+		baseline = previous
+	}
+	return previous, baseline
+}
+
+// containsFormat verifica se o formato já está na lista
+func containsFormat(formats []string, format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
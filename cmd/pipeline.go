@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/insights"
+	"github-octokit-poc/internal/output"
+	"github-octokit-poc/utils"
+)
+
+// runPipeline executa extração, relatório, gravação dos outputs e avaliação
+// dos alertas de um repositório. Com verbose=false apenas os arquivos são
+// gerados, sem imprimir resumo e insights no terminal. Os dados são retornados
+// mesmo quando algum formato falha ao ser gravado.
+func runPipeline(client *github.Client, owner, repo string, handler *output.Handler, opts runOptions, verbose bool) (*extractor.RepositoryData, error) {
+	// 1. Extrair dados do repositório
+	data, err := extractor.ExtractRepositoryData(client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, writeOutputs(data, handler, opts, verbose)
+}
+
+// writeOutputs gera o relatório, salva os arquivos, avalia os alertas e exibe
+// os insights. Retorna o erro agregado dos formatos que não puderam ser
+// gravados; falhas no envio de alertas são apenas registradas no log.
+func writeOutputs(data *extractor.RepositoryData, handler *output.Handler, opts runOptions, verbose bool) error {
+	// 2. Exibir resumo
+	if verbose {
+		data.PrintSummary()
+	}
+
+	// 3. Executar analisadores e gerar relatório detalhado
+	analysis, previous := opts.analyze(data)
+	report := utils.GenerateTextReport(data, analysis)
+	if verbose {
+		fmt.Println("\n" + report)
+	}
+
+	// 4. Salvar outputs
+	saveErr := handler.SaveAll(data, analysis)
+	if saveErr != nil {
+		log.Printf("⚠️ Erro ao salvar outputs: %v", saveErr)
+	}
+
+	// 5. Avaliar regras de alerta
+	if _, err := opts.Alerts.Process(data, analysis, previous); err != nil {
+		log.Printf("⚠️ Erro ao enviar alertas: %v", err)
+	}
+
+	// 6. Mostrar insights específicos
+	if verbose {
+		insights.ShowDetailedInsights(data)
+	}
+
+	return saveErr
+}
@@ -1,59 +1,70 @@
 package cmd
 
 import (
-	"fmt"
 	"log"
 
-	"github-octokit-poc/extractor"
 	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cli"
 	"github-octokit-poc/internal/config"
-	"github-octokit-poc/internal/insights"
 	"github-octokit-poc/internal/output"
-	"github-octokit-poc/utils"
 )
 
 // Run é o ponto de entrada principal da aplicação
 func Run() error {
-	log.Println("🚀 Iniciando POC do GitHub Octokit em Go")
+	log.Println("🚀 Iniciando GitHub Repository Analyzer")
 
-	// 1. Carregar configurações
-	cfg, err := config.Load()
+	// 1. Ler argumentos da linha de comando
+	args, err := cli.Parse()
 	if err != nil {
 		return err
 	}
 
-	// 2. Criar cliente GitHub
-	client, err := github.NewClient()
+	// 2. Carregar configurações
+	cfg, err := config.Load()
 	if err != nil {
 		return err
 	}
-	log.Println("✅ Cliente GitHub configurado com sucesso")
 
-	// 3. Definir repositório alvo
-	owner, repo := cfg.GetTarget()
-	log.Printf("🎯 Alvo: %s/%s", owner, repo)
-
-	// 4. Extrair dados do repositório
-	data, err := extractor.ExtractRepositoryData(client, owner, repo)
+	opts, err := newRunOptions(cfg, args)
 	if err != nil {
 		return err
 	}
 
-	// 5. Exibir resumo
-	data.PrintSummary()
-
-	// 6. Gerar relatório detalhado
-	report := utils.GenerateReport(data)
-	fmt.Println("\n" + report)
+	// 3. Criar cliente GitHub
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Cliente GitHub configurado com sucesso")
 
-	// 7. Salvar outputs
-	outputHandler := output.NewHandler(owner, repo)
-	if err := outputHandler.SaveAll(data, report); err != nil {
-		log.Printf("⚠️ Erro ao salvar outputs: %v", err)
+	// 4. Subcomandos e execução em lote
+	switch args.Command {
+	case cli.CommandCompare:
+		return runCompare(client, args.Targets, opts.OutputDir, opts.Analyzer)
+	case cli.CommandChangelog:
+		return runChangelog(client, args.Targets[0], args.From, args.To, opts.OutputDir)
+	case cli.CommandServeMetrics:
+		return runServeMetrics(client, cfg, args, opts.Analyzer)
+	case cli.CommandServe:
+		return runServe(client, cfg, args, opts.Analyzer)
+	case cli.CommandDaemon:
+		return runDaemon(client, cfg, args, opts)
+	}
+	if args.IsBatch() {
+		return runBatch(client, args.BatchFile, opts)
 	}
 
-	// 8. Mostrar insights específicos
-	insights.ShowDetailedInsights(data)
+	// 5. Definir repositório alvo
+	owner, repo := cfg.GetTarget()
+	if !args.IsEmpty() {
+		owner, repo = args.GetTarget()
+		log.Printf("🎯 Alvo (via CLI): %s/%s", owner, repo)
+	} else {
+		log.Printf("🎯 Alvo (via configuração): %s/%s", owner, repo)
+	}
 
-	return nil
-}
\ No newline at end of file
+	// 6. Executar pipeline completo
+	handler := opts.newHandler(owner, repo, output.NewTimestamp())
+	_, err = runPipeline(client, owner, repo, handler, opts, true)
+	return err
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/config"
+	"github-octokit-poc/internal/server"
+	"github-octokit-poc/utils"
+)
+
+// runServe inicia a API HTTP de análise de repositórios até receber SIGINT
+// ou SIGTERM
+func runServe(client *github.Client, cfg *config.Config, args *cli.Args, analyzer utils.Analyzer) error {
+	addr := cfg.ServerAddr
+	if args.Addr != "" {
+		addr = args.Addr
+	}
+	ttl := cfg.CacheTTL
+	if args.CacheTTL > 0 {
+		ttl = args.CacheTTL
+	}
+
+	allowlist, err := serveAllowlist(cfg, args)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	api := server.New(client, ttl, cfg.CacheSize, allowlist, analyzer)
+
+	log.Printf("🌐 API HTTP em %s (cache de %s, até %d repositórios)", addr, ttl, cfg.CacheSize)
+	log.Println("   GET /repos/{owner}/{repo}/analysis")
+	log.Println("   GET /repos/{owner}/{repo}/report?format=md|txt|html")
+	log.Println("   GET /repos/{owner}/{repo}/health")
+	log.Println("   GET /health")
+	if cfg.WebhookSecret != "" {
+		api.EnableWebhooks(cfg.WebhookSecret)
+		log.Println("   POST /webhook (push, issues, pull_request, release)")
+	} else {
+		log.Println("ℹ️ Webhooks desativados: defina GITHUB_WEBHOOK_SECRET para habilitar POST /webhook")
+	}
+	return serveUntilDone(ctx, &http.Server{Addr: addr, Handler: api})
+}
+
+// serveAllowlist monta a allowlist do serve a partir de SERVER_ALLOWLIST e do
+// arquivo --batch; sem ela o servidor não inicia, pois qualquer cliente
+// poderia gastar o rate limit do token com repositórios arbitrários
+func serveAllowlist(cfg *config.Config, args *cli.Args) (*server.Allowlist, error) {
+	entries := append([]string(nil), cfg.ServerAllowlist...)
+	if args.IsBatch() {
+		targets, err := cli.LoadTargetsFile(args.BatchFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range targets {
+			entries = append(entries, target.FullName())
+		}
+	}
+
+	allowlist, err := server.ParseAllowlist(entries)
+	if err != nil {
+		return nil, err
+	}
+	if allowlist.Empty() {
+		return nil, fmt.Errorf("o serve exige uma allowlist: defina SERVER_ALLOWLIST (ex: \"kubernetes/*,golang/go\") ou use --batch; \"*\" libera qualquer repositório")
+	}
+	log.Printf("🔒 Allowlist com %d entradas", len(entries))
+	return allowlist, nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github-octokit-poc/github"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/config"
+	"github-octokit-poc/internal/metrics"
+	"github-octokit-poc/utils"
+)
+
+// shutdownTimeout limita o tempo de espera do desligamento gracioso
+const shutdownTimeout = 10 * time.Second
+
+// runServeMetrics inicia o exporter Prometheus, atualizando os repositórios
+// periodicamente até receber SIGINT ou SIGTERM
+func runServeMetrics(client *github.Client, cfg *config.Config, args *cli.Args, analyzer utils.Analyzer) error {
+	targets, err := resolveTargets(cfg, args)
+	if err != nil {
+		return err
+	}
+
+	addr := cfg.MetricsAddr
+	if args.Addr != "" {
+		addr = args.Addr
+	}
+	interval := cfg.MetricsInterval
+	if args.Interval > 0 {
+		interval = args.Interval
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exporter := metrics.NewExporter(client, targets, interval, analyzer)
+	go exporter.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "GitHub Repository Analyzer - métricas em /metrics")
+	})
+
+	log.Printf("📡 Exporter Prometheus em %s/metrics (%d repositórios, atualização a cada %s)", addr, len(targets), interval)
+	return serveUntilDone(ctx, &http.Server{Addr: addr, Handler: mux})
+}
+
+// serveUntilDone executa o servidor HTTP e faz o desligamento gracioso
+// quando o contexto é cancelado
+func serveUntilDone(ctx context.Context, server *http.Server) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("🛑 Encerrando servidor...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("erro no desligamento do servidor: %v", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	log.Println("✅ Servidor encerrado")
+	return nil
+}
+
+// resolveTargets combina os repositórios posicionais e do arquivo --batch;
+// sem nenhum informado, usa o repositório padrão da configuração
+func resolveTargets(cfg *config.Config, args *cli.Args) ([]cli.Target, error) {
+	targets := append([]cli.Target(nil), args.Targets...)
+
+	if args.IsBatch() {
+		fromFile, err := cli.LoadTargetsFile(args.BatchFile)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, fromFile...)
+	}
+
+	if len(targets) == 0 {
+		owner, repo := cfg.GetTarget()
+		targets = append(targets, cli.Target{Owner: owner, Repo: repo, Source: "config"})
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,261 @@
+package extractor
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// changelogMaxPages limita as páginas (de 100 commits) lidas da comparação
+const changelogMaxPages = 5
+
+// changelogMaxPullLookups limita as consultas de pull request por commit
+// (uma requisição por commit); acima disso vale o número citado na mensagem
+const changelogMaxPullLookups = 200
+
+// changelogMaxAuthorChecks limita quantos autores são verificados como
+// primeira contribuição (uma requisição por autor)
+const changelogMaxAuthorChecks = 50
+
+// pullNumberPatterns reconhecem o número do PR na mensagem de merge
+// ("Merge pull request #12 from ...") e de squash ("Título (#12)")
+var pullNumberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^Merge pull request #(\d+)`),
+	regexp.MustCompile(`\(#(\d+)\)\s*$`),
+}
+
+// ChangelogData reúne os commits entre duas refs e os pull requests
+// mergeados associados a eles
+type ChangelogData struct {
+	Owner        string `json:"owner"`
+	Repo         string `json:"repo"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	CompareURL   string `json:"compare_url"`
+	TotalCommits int    `json:"total_commits"`
+	// Truncated indica que a comparação tinha mais commits do que o limite de páginas
+	Truncated    bool                    `json:"truncated,omitempty"`
+	Commits      []*ChangelogCommit      `json:"commits"`
+	PullRequests []*ChangelogPullRequest `json:"pull_requests"`
+	// FirstTimeContributors são os autores sem commits alcançáveis a partir de From
+	FirstTimeContributors []string  `json:"first_time_contributors"`
+	GeneratedAt           time.Time `json:"generated_at"`
+}
+
+// ChangelogCommit é um commit da comparação
+type ChangelogCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	// Author é o login no GitHub ou, sem conta associada, o nome do git
+	Author string `json:"author"`
+	Login  bool   `json:"login"`
+	Merge  bool   `json:"merge"`
+	// PullRequest é o número do PR mergeado que contém o commit (0: nenhum)
+	PullRequest int       `json:"pull_request,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	URL         string    `json:"url"`
+}
+
+// ChangelogPullRequest é um pull request mergeado com commits na comparação
+type ChangelogPullRequest struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body,omitempty"`
+	Author   string    `json:"author"`
+	Labels   []string  `json:"labels,omitempty"`
+	MergedAt time.Time `json:"merged_at"`
+	URL      string    `json:"url"`
+}
+
+// ListReleases lista os releases mais recentes, usados para escolher as refs
+// padrão do changelog
+func ListReleases(client *ghclient.Client, owner, repo string) ([]*ReleaseData, error) {
+	data := &RepositoryData{}
+	if err := extractReleases(client, owner, repo, data); err != nil {
+		return nil, fmt.Errorf("erro ao listar releases: %v", err)
+	}
+	return data.Releases, nil
+}
+
+// ExtractChangelog compara as refs (tags, branches ou SHAs), associa cada
+// commit ao pull request mergeado que o contém e identifica os autores que
+// contribuem pela primeira vez
+func ExtractChangelog(client *ghclient.Client, owner, repo, from, to string) (*ChangelogData, error) {
+	data := &ChangelogData{Owner: owner, Repo: repo, From: from, To: to, GeneratedAt: time.Now()}
+
+	log.Printf("🔀 Comparando %s...%s", from, to)
+	if err := extractComparison(client, data); err != nil {
+		return nil, fmt.Errorf("erro ao comparar %s...%s: %v", from, to, err)
+	}
+	log.Printf("✅ %d commits na comparação", len(data.Commits))
+
+	log.Println("🔗 Associando commits a pull requests...")
+	associatePullRequests(client, data)
+
+	log.Println("🎉 Verificando primeiras contribuições...")
+	findFirstTimeContributors(client, data)
+
+	return data, nil
+}
+
+// extractComparison lê os commits da comparação, do mais antigo para o mais novo
+func extractComparison(client *ghclient.Client, data *ChangelogData) error {
+	opts := &github.ListOptions{PerPage: 100}
+	for page := 0; page < changelogMaxPages; page++ {
+		comparison, resp, err := client.GitHub.Repositories.CompareCommits(client.Ctx, data.Owner, data.Repo, data.From, data.To, opts)
+		if err != nil {
+			return err
+		}
+		data.CompareURL = comparison.GetHTMLURL()
+		data.TotalCommits = comparison.GetTotalCommits()
+
+		for _, commit := range comparison.Commits {
+			author, login := commit.GetAuthor().GetLogin(), true
+			if author == "" {
+				author, login = commit.GetCommit().GetAuthor().GetName(), false
+			}
+			data.Commits = append(data.Commits, &ChangelogCommit{
+				SHA:       commit.GetSHA(),
+				Message:   commit.GetCommit().GetMessage(),
+				Author:    author,
+				Login:     login,
+				Merge:     len(commit.Parents) > 1,
+				CreatedAt: commit.GetCommit().GetAuthor().GetDate().Time,
+				URL:       commit.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+	data.Truncated = len(data.Commits) < data.TotalCommits
+	return nil
+}
+
+// associatePullRequests consulta o PR mergeado de cada commit. Commits de um
+// PR já conhecido (pelo número citado na mensagem) não são consultados de
+// novo; acima de changelogMaxPullLookups fica apenas o número da mensagem.
+func associatePullRequests(client *ghclient.Client, data *ChangelogData) {
+	known := make(map[int]bool)
+	lookups := 0
+	for _, commit := range data.Commits {
+		if number := pullNumberFromMessage(commit.Message); number > 0 && known[number] {
+			commit.PullRequest = number
+			continue
+		}
+		if lookups >= changelogMaxPullLookups {
+			commit.PullRequest = pullNumberFromMessage(commit.Message)
+			continue
+		}
+		lookups++
+
+		pulls, _, err := client.GitHub.PullRequests.ListPullRequestsWithCommit(client.Ctx, data.Owner, data.Repo, commit.SHA, nil)
+		if err != nil {
+			log.Printf("⚠️ Erro ao consultar PRs do commit %s: %v", shortSHA(commit.SHA), err)
+			commit.PullRequest = pullNumberFromMessage(commit.Message)
+			continue
+		}
+		pull := mergedPullRequest(pulls, commit.SHA)
+		if pull == nil {
+			continue
+		}
+		commit.PullRequest = pull.GetNumber()
+		if known[pull.GetNumber()] {
+			continue
+		}
+		known[pull.GetNumber()] = true
+
+		labels := make([]string, len(pull.Labels))
+		for i, label := range pull.Labels {
+			labels[i] = label.GetName()
+		}
+		data.PullRequests = append(data.PullRequests, &ChangelogPullRequest{
+			Number:   pull.GetNumber(),
+			Title:    pull.GetTitle(),
+			Body:     pull.GetBody(),
+			Author:   pull.GetUser().GetLogin(),
+			Labels:   labels,
+			MergedAt: pull.GetMergedAt().Time,
+			URL:      pull.GetHTMLURL(),
+		})
+	}
+	if lookups >= changelogMaxPullLookups {
+		log.Printf("⚠️ Limite de %d consultas de PR atingido; demais commits usam o número citado na mensagem", changelogMaxPullLookups)
+	}
+}
+
+// mergedPullRequest escolhe o PR mergeado do commit, preferindo aquele cujo
+// merge gerou o próprio commit
+func mergedPullRequest(pulls []*github.PullRequest, sha string) *github.PullRequest {
+	var merged *github.PullRequest
+	for _, pull := range pulls {
+		if pull.MergedAt == nil {
+			continue
+		}
+		if pull.GetMergeCommitSHA() == sha {
+			return pull
+		}
+		if merged == nil {
+			merged = pull
+		}
+	}
+	return merged
+}
+
+// pullNumberFromMessage retorna o número do PR citado na primeira linha da
+// mensagem ou 0
+func pullNumberFromMessage(message string) int {
+	subject, _, _ := strings.Cut(message, "\n")
+	for _, pattern := range pullNumberPatterns {
+		if match := pattern.FindStringSubmatch(subject); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			return number
+		}
+	}
+	return 0
+}
+
+// findFirstTimeContributors verifica, para cada login da comparação, se há
+// commits dele alcançáveis a partir de From. Autores sem conta no GitHub não
+// são verificados.
+func findFirstTimeContributors(client *ghclient.Client, data *ChangelogData) {
+	seen := make(map[string]bool)
+	checks := 0
+	for _, commit := range data.Commits {
+		if !commit.Login || seen[commit.Author] {
+			continue
+		}
+		seen[commit.Author] = true
+		if checks >= changelogMaxAuthorChecks {
+			log.Printf("⚠️ Limite de %d autores verificados atingido", changelogMaxAuthorChecks)
+			return
+		}
+		checks++
+
+		opts := &github.CommitsListOptions{SHA: data.From, Author: commit.Author, ListOptions: github.ListOptions{PerPage: 1}}
+		previous, _, err := client.GitHub.Repositories.ListCommits(client.Ctx, data.Owner, data.Repo, opts)
+		if err != nil {
+			log.Printf("⚠️ Erro ao verificar commits anteriores de %s: %v", commit.Author, err)
+			continue
+		}
+		if len(previous) == 0 {
+			data.FirstTimeContributors = append(data.FirstTimeContributors, commit.Author)
+		}
+	}
+}
+
+// shortSHA abrevia o SHA para exibição
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,287 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	ghclient "github-octokit-poc/github"
+)
+
+// codeownersPaths são os locais onde o GitHub procura o CODEOWNERS, na ordem
+// de precedência (o primeiro encontrado é o usado)
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersMaxOwners limita quantos donos são validados individualmente
+// (uma requisição por usuário, até duas por time)
+const codeownersMaxOwners = 30
+
+// Tipos de dono no CODEOWNERS
+const (
+	OwnerKindUser  = "user"
+	OwnerKindTeam  = "team"
+	OwnerKindEmail = "email"
+)
+
+// Situação de um dono após a validação
+const (
+	// OwnerStatusOK indica acesso de escrita ao repositório
+	OwnerStatusOK = "ok"
+	// OwnerStatusNotFound indica usuário ou time inexistente
+	OwnerStatusNotFound = "not_found"
+	// OwnerStatusNoWrite indica dono sem permissão de escrita (ou time sem acesso)
+	OwnerStatusNoWrite = "no_write"
+	// OwnerStatusInvalid indica dono apontado pelo GitHub como desconhecido
+	// (inexistente ou sem escrita) sem que a causa pudesse ser verificada
+	OwnerStatusInvalid = "invalid"
+	// OwnerStatusUnknown indica dono não verificável (email, token sem
+	// permissão ou acima de codeownersMaxOwners)
+	OwnerStatusUnknown = "unknown"
+)
+
+// CodeOwnersFile guarda o CODEOWNERS do branch padrão já interpretado
+type CodeOwnersFile struct {
+	Found  bool               `json:"found"`
+	Path   string             `json:"path,omitempty"`
+	URL    string             `json:"url,omitempty"`
+	Rules  []*CodeOwnersRule  `json:"rules,omitempty"`
+	Owners []*CodeOwner       `json:"owners,omitempty"`
+	Errors []*CodeOwnersError `json:"errors,omitempty"`
+}
+
+// CodeOwnersRule representa uma linha de regra (padrão seguido dos donos);
+// uma regra sem donos remove a posse dos arquivos que casam com o padrão
+type CodeOwnersRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// CodeOwner representa um dono citado no CODEOWNERS e a sua validação
+type CodeOwner struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Status     string `json:"status"`
+	Permission string `json:"permission,omitempty"`
+	Rules      int    `json:"rules"`
+}
+
+// CodeOwnersError é um erro reportado pelo GitHub para o CODEOWNERS
+type CodeOwnersError struct {
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// extractCodeOwners procura o CODEOWNERS nos locais padrão, interpreta as
+// regras, consulta os erros apontados pelo GitHub e valida cada dono contra
+// as permissões do repositório
+func extractCodeOwners(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	codeowners := &CodeOwnersFile{}
+
+	var content string
+	for _, path := range codeownersPaths {
+		file, _, _, err := client.GitHub.Repositories.GetContents(client.Ctx, owner, repo, path, nil)
+		if isNotFound(err) || (err == nil && file == nil) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if content, err = file.GetContent(); err != nil {
+			return fmt.Errorf("erro ao decodificar %s: %v", path, err)
+		}
+		codeowners.Found = true
+		codeowners.Path = file.GetPath()
+		codeowners.URL = file.GetHTMLURL()
+		break
+	}
+	data.CodeOwners = codeowners
+	if !codeowners.Found {
+		return nil
+	}
+
+	codeowners.Rules = parseCodeOwners(content)
+	codeowners.Owners = collectOwners(codeowners.Rules)
+
+	var errs []error
+	apiErrors, _, err := client.GitHub.Repositories.GetCodeownersErrors(client.Ctx, owner, repo, nil)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("erros do CODEOWNERS: %v", err))
+	} else {
+		for _, apiError := range apiErrors.Errors {
+			codeowners.Errors = append(codeowners.Errors, &CodeOwnersError{
+				Line:       apiError.Line,
+				Column:     apiError.Column,
+				Kind:       apiError.Kind,
+				Message:    apiError.Message,
+				Suggestion: apiError.GetSuggestion(),
+			})
+		}
+	}
+
+	var unverified int
+	var firstErr error
+	for i, codeOwner := range codeowners.Owners {
+		if codeOwner.Kind == OwnerKindEmail || i >= codeownersMaxOwners {
+			continue
+		}
+		if err := validateOwner(client, owner, repo, codeOwner); err != nil {
+			unverified++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if unverified > 0 {
+		errs = append(errs, fmt.Errorf("%d donos não puderam ser validados: %v", unverified, firstErr))
+	}
+
+	markUnknownOwners(codeowners, content)
+	return errors.Join(errs...)
+}
+
+// parseCodeOwners interpreta as linhas do CODEOWNERS, ignorando comentários
+// e linhas em branco
+func parseCodeOwners(content string) []*CodeOwnersRule {
+	var rules []*CodeOwnersRule
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(stripCodeOwnersComment(line))
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, &CodeOwnersRule{
+			Line:    i + 1,
+			Pattern: fields[0],
+			Owners:  fields[1:],
+		})
+	}
+	return rules
+}
+
+// stripCodeOwnersComment remove o comentário da linha: "#" no início ou após
+// um espaço, exceto quando escapado ("\#")
+func stripCodeOwnersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if i == 0 || unicode.IsSpace(rune(line[i-1])) {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// collectOwners lista os donos distintos na ordem em que aparecem
+func collectOwners(rules []*CodeOwnersRule) []*CodeOwner {
+	var owners []*CodeOwner
+	index := make(map[string]*CodeOwner)
+	for _, rule := range rules {
+		for _, name := range rule.Owners {
+			key := strings.ToLower(name)
+			codeOwner, ok := index[key]
+			if !ok {
+				codeOwner = &CodeOwner{Name: name, Kind: ownerKind(name), Status: OwnerStatusUnknown}
+				index[key] = codeOwner
+				owners = append(owners, codeOwner)
+			}
+			codeOwner.Rules++
+		}
+	}
+	return owners
+}
+
+// ownerKind identifica o tipo do dono: @usuario, @org/time ou email
+func ownerKind(name string) string {
+	switch {
+	case !strings.HasPrefix(name, "@"):
+		return OwnerKindEmail
+	case strings.Contains(name, "/"):
+		return OwnerKindTeam
+	default:
+		return OwnerKindUser
+	}
+}
+
+// validateOwner consulta a permissão do usuário ou do time no repositório.
+// Retorna erro apenas quando a situação não pôde ser determinada.
+func validateOwner(client *ghclient.Client, owner, repo string, codeOwner *CodeOwner) error {
+	name := strings.TrimPrefix(codeOwner.Name, "@")
+
+	if codeOwner.Kind == OwnerKindUser {
+		level, _, err := client.GitHub.Repositories.GetPermissionLevel(client.Ctx, owner, repo, name)
+		if isNotFound(err) {
+			codeOwner.Status = OwnerStatusNotFound
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		codeOwner.Permission = level.GetPermission()
+		codeOwner.Status = OwnerStatusNoWrite
+		if codeOwner.Permission == "admin" || codeOwner.Permission == "write" {
+			codeOwner.Status = OwnerStatusOK
+		}
+		return nil
+	}
+
+	org, slug, _ := strings.Cut(name, "/")
+	repository, _, err := client.GitHub.Teams.IsTeamRepoBySlug(client.Ctx, org, slug, owner, repo)
+	if isNotFound(err) {
+		// O time pode existir sem acesso ao repositório
+		_, _, err = client.GitHub.Teams.GetTeamBySlug(client.Ctx, org, slug)
+		switch {
+		case isNotFound(err):
+			codeOwner.Status = OwnerStatusNotFound
+		case err == nil:
+			codeOwner.Status = OwnerStatusNoWrite
+			codeOwner.Permission = "none"
+		default:
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	permissions := repository.GetPermissions()
+	codeOwner.Status = OwnerStatusNoWrite
+	codeOwner.Permission = "read"
+	for _, permission := range []string{"admin", "maintain", "push"} {
+		if permissions[permission] {
+			codeOwner.Status = OwnerStatusOK
+			codeOwner.Permission = permission
+			break
+		}
+	}
+	return nil
+}
+
+// markUnknownOwners marca como inválidos os donos que o GitHub reportou como
+// desconhecidos e que não puderam ser validados individualmente
+func markUnknownOwners(codeowners *CodeOwnersFile, content string) {
+	lines := strings.Split(content, "\n")
+	for _, apiError := range codeowners.Errors {
+		if !strings.EqualFold(apiError.Kind, "Unknown owner") || apiError.Line < 1 || apiError.Line > len(lines) {
+			continue
+		}
+		line := lines[apiError.Line-1]
+		if apiError.Column < 1 || apiError.Column > len(line) {
+			continue
+		}
+		name := strings.Fields(line[apiError.Column-1:])
+		if len(name) == 0 {
+			continue
+		}
+		for _, codeOwner := range codeowners.Owners {
+			if strings.EqualFold(codeOwner.Name, name[0]) && codeOwner.Status == OwnerStatusUnknown {
+				codeOwner.Status = OwnerStatusInvalid
+			}
+		}
+	}
+}
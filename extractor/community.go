@@ -0,0 +1,166 @@
+package extractor
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Arquivos de comunidade verificados
+const (
+	CommunityReadme              = "readme"
+	CommunityContributing        = "contributing"
+	CommunityCodeOfConduct       = "code_of_conduct"
+	CommunitySecurity            = "security"
+	CommunityIssueTemplate       = "issue_template"
+	CommunityPullRequestTemplate = "pull_request_template"
+	CommunityLicense             = "license"
+)
+
+// Origens da detecção de um arquivo de comunidade
+const (
+	// CommunitySourceProfile indica o endpoint de community profile, que também
+	// considera os arquivos padrão do repositório .github da organização
+	CommunitySourceProfile = "community_profile"
+	// CommunitySourceContents indica a listagem de conteúdo do repositório
+	CommunitySourceContents = "contents"
+)
+
+// communityDirs são os diretórios onde o GitHub procura arquivos de comunidade
+var communityDirs = []string{"", ".github", "docs"}
+
+// communityNames associa cada arquivo aos nomes aceitos (sem extensão e sem
+// diferenciar maiúsculas); templates também podem ser diretórios
+var communityNames = map[string][]string{
+	CommunityReadme:              {"readme"},
+	CommunityContributing:        {"contributing"},
+	CommunityCodeOfConduct:       {"code_of_conduct", "code-of-conduct"},
+	CommunitySecurity:            {"security"},
+	CommunityIssueTemplate:       {"issue_template"},
+	CommunityPullRequestTemplate: {"pull_request_template"},
+	CommunityLicense:             {"license", "licence", "copying"},
+}
+
+// CommunityFileOrder define a ordem de exibição dos arquivos de comunidade
+var CommunityFileOrder = []string{
+	CommunityReadme,
+	CommunityLicense,
+	CommunityContributing,
+	CommunityCodeOfConduct,
+	CommunitySecurity,
+	CommunityIssueTemplate,
+	CommunityPullRequestTemplate,
+}
+
+// CommunityProfile guarda o resultado do community profile e das verificações
+// diretas de conteúdo
+type CommunityProfile struct {
+	HealthPercentage int                       `json:"health_percentage"`
+	ProfileAvailable bool                      `json:"profile_available"`
+	Files            map[string]*CommunityFile `json:"files"`
+}
+
+// CommunityFile indica se um arquivo de comunidade foi encontrado e onde
+type CommunityFile struct {
+	Present bool   `json:"present"`
+	Path    string `json:"path,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Source  string `json:"source,omitempty"`
+}
+
+// extractCommunityProfile combina o endpoint de community profile com a
+// listagem da raiz, de .github/ e de docs/. O profile não informa SECURITY.md
+// nem o caminho dos arquivos; a listagem não enxerga os padrões da organização.
+func extractCommunityProfile(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	community := &CommunityProfile{Files: make(map[string]*CommunityFile)}
+	for _, key := range CommunityFileOrder {
+		community.Files[key] = &CommunityFile{}
+	}
+
+	var errs []error
+	metrics, _, err := client.GitHub.Repositories.GetCommunityHealthMetrics(client.Ctx, owner, repo)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		community.ProfileAvailable = true
+		community.HealthPercentage = metrics.GetHealthPercentage()
+		if files := metrics.Files; files != nil {
+			codeOfConduct := files.CodeOfConductFile
+			if codeOfConduct == nil {
+				codeOfConduct = files.CodeOfConduct
+			}
+			for key, metric := range map[string]*github.Metric{
+				CommunityReadme:              files.Readme,
+				CommunityContributing:        files.Contributing,
+				CommunityCodeOfConduct:       codeOfConduct,
+				CommunityIssueTemplate:       files.IssueTemplate,
+				CommunityPullRequestTemplate: files.PullRequestTemplate,
+				CommunityLicense:             files.License,
+			} {
+				if metric != nil {
+					community.Files[key].Present = true
+					community.Files[key].URL = metric.GetHTMLURL()
+					community.Files[key].Source = CommunitySourceProfile
+				}
+			}
+		}
+	}
+
+	for _, dir := range communityDirs {
+		_, entries, _, err := client.GitHub.Repositories.GetContents(client.Ctx, owner, repo, dir, nil)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, entry := range entries {
+			key := communityKey(entry.GetName())
+			if key == "" || community.Files[key].Path != "" {
+				continue // A primeira ocorrência (raiz, .github, docs) prevalece
+			}
+			file := community.Files[key]
+			file.Present = true
+			file.Path = entry.GetPath()
+			if file.URL == "" {
+				file.URL = entry.GetHTMLURL()
+			}
+			if file.Source == "" {
+				file.Source = CommunitySourceContents
+			}
+		}
+	}
+
+	// Mesmo com falhas parciais, o que foi encontrado é mantido
+	if community.ProfileAvailable || len(errs) < 1+len(communityDirs) {
+		data.Community = community
+	}
+	return errors.Join(errs...)
+}
+
+// communityKey identifica o arquivo de comunidade pelo nome, ignorando a
+// extensão e maiúsculas (ex: CONTRIBUTING.md, contributing.rst, ISSUE_TEMPLATE/)
+func communityKey(name string) string {
+	stem := strings.ToLower(strings.TrimSuffix(name, path.Ext(name)))
+	for key, names := range communityNames {
+		for _, candidate := range names {
+			if stem == candidate {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// isNotFound verifica se o erro da API é um 404
+func isNotFound(err error) bool {
+	var errorResponse *github.ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.Response != nil &&
+		errorResponse.Response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,396 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	ghclient "github-octokit-poc/github"
+)
+
+// manifestMaxFiles limita quantos manifestos são lidos (uma requisição cada)
+const manifestMaxFiles = 20
+
+// Ecossistemas, com os mesmos identificadores de tipo do Package URL (purl)
+const (
+	EcosystemGo    = "golang"
+	EcosystemNPM   = "npm"
+	EcosystemPyPI  = "pypi"
+	EcosystemMaven = "maven"
+)
+
+// Relação da dependência com o repositório
+const (
+	DependencyDirect   = "direct"
+	DependencyIndirect = "indirect"
+	// DependencyUnknown indica dependência vista apenas no SBOM, de um
+	// ecossistema sem manifesto lido
+	DependencyUnknown = "unknown"
+)
+
+// Escopos de uma dependência
+const (
+	ScopeRuntime     = "runtime"
+	ScopeDevelopment = "development"
+	ScopePeer        = "peer"
+	ScopeOptional    = "optional"
+)
+
+// Origens de uma dependência
+const (
+	DependencySourceSBOM     = "sbom"
+	DependencySourceManifest = "manifest"
+)
+
+// DependencyData reúne o SBOM do dependency graph e os manifestos lidos
+type DependencyData struct {
+	SBOMAvailable bool          `json:"sbom_available"`
+	SBOMCreatedAt time.Time     `json:"sbom_created_at"`
+	Manifests     []*Manifest   `json:"manifests"`
+	Dependencies  []*Dependency `json:"dependencies"`
+}
+
+// Manifest é um arquivo de manifesto encontrado na árvore
+type Manifest struct {
+	Path         string `json:"path"`
+	Ecosystem    string `json:"ecosystem"`
+	Dependencies int    `json:"dependencies"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Dependency é uma dependência do repositório
+type Dependency struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Ecosystem    string   `json:"ecosystem"`
+	Relationship string   `json:"relationship"`
+	Scope        string   `json:"scope,omitempty"`
+	PURL         string   `json:"purl,omitempty"`
+	License      string   `json:"license,omitempty"`
+	Manifest     string   `json:"manifest,omitempty"`
+	Sources      []string `json:"sources"`
+}
+
+// spdxDocument é o subconjunto do SBOM (SPDX 2.3) usado na extração. O tipo
+// SBOM do go-github não traz externalRefs, necessários para o ecossistema.
+type spdxDocument struct {
+	SBOM struct {
+		CreationInfo struct {
+			Created time.Time `json:"created"`
+		} `json:"creationInfo"`
+		DocumentDescribes []string `json:"documentDescribes"`
+		Packages          []struct {
+			SPDXID           string `json:"SPDXID"`
+			Name             string `json:"name"`
+			VersionInfo      string `json:"versionInfo"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+			ExternalRefs     []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	} `json:"sbom"`
+}
+
+// extractDependencies combina o SBOM do dependency graph (versões resolvidas,
+// inclusive transitivas) com os manifestos da árvore (relação direta/indireta
+// e escopo). Cada fonte é independente; as falhas vão no erro agregado.
+func extractDependencies(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	deps := &DependencyData{}
+	index := make(map[string]*Dependency)
+	var errs []error
+
+	// Manifestos primeiro: definem a relação das dependências diretas. Arquivos
+	// incluídos por um manifesto (ex: -r no requirements.txt) entram na fila
+	// com o parser de quem os incluiu.
+	if data.Tree != nil {
+		var pending []manifestFile
+		queued := make(map[string]bool)
+		for _, manifestPath := range findManifests(data.Tree) {
+			pending = append(pending, manifestFile{path: manifestPath, parser: manifestParsers[path.Base(manifestPath)]})
+			queued[manifestPath] = true
+		}
+		for i := 0; i < len(pending); i++ {
+			if i == manifestMaxFiles {
+				errs = append(errs, fmt.Errorf("%d manifestos encontrados, apenas %d lidos", len(pending), manifestMaxFiles))
+				break
+			}
+			manifest, parsed, includes := readManifest(client, owner, repo, pending[i])
+			deps.Manifests = append(deps.Manifests, manifest)
+			for _, dep := range parsed {
+				mergeDependency(index, dep)
+			}
+			for _, include := range includes {
+				if !queued[include] {
+					queued[include] = true
+					pending = append(pending, manifestFile{path: include, parser: pending[i].parser})
+				}
+			}
+		}
+	}
+
+	sbomErr := extractSBOM(client, owner, repo, deps, index)
+	if sbomErr != nil {
+		errs = append(errs, fmt.Errorf("SBOM: %v", sbomErr))
+	}
+
+	// Dependências só do SBOM são transitivas quando o manifesto do
+	// ecossistema foi lido, e desconhecidas caso contrário
+	parsedEcosystems := make(map[string]bool)
+	for _, manifest := range deps.Manifests {
+		if manifest.Error == "" {
+			parsedEcosystems[manifest.Ecosystem] = true
+		}
+	}
+	for _, dep := range index {
+		if dep.Relationship == "" {
+			dep.Relationship = DependencyUnknown
+			if parsedEcosystems[dep.Ecosystem] {
+				dep.Relationship = DependencyIndirect
+			}
+		}
+		if dep.PURL == "" {
+			dep.PURL = BuildPURL(dep.Ecosystem, dep.Name, dep.Version)
+		}
+		deps.Dependencies = append(deps.Dependencies, dep)
+	}
+	sort.Slice(deps.Dependencies, func(i, j int) bool {
+		a, b := deps.Dependencies[i], deps.Dependencies[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		return a.Name < b.Name
+	})
+
+	if deps.SBOMAvailable || len(deps.Manifests) > 0 {
+		data.Dependencies = deps
+	}
+	return errors.Join(errs...)
+}
+
+// manifestFile é um manifesto a ser lido, com o parser do seu tipo
+type manifestFile struct {
+	path   string
+	parser manifestParser
+}
+
+// readManifest baixa e interpreta um manifesto e retorna também os arquivos
+// que ele inclui, com caminhos a partir da raiz; erros ficam no próprio
+// manifesto para não esconder os demais
+func readManifest(client *ghclient.Client, owner, repo string, file manifestFile) (*Manifest, []*Dependency, []string) {
+	manifestPath, parser := file.path, file.parser
+	manifest := &Manifest{Path: manifestPath, Ecosystem: parser.ecosystem}
+
+	contents, _, _, err := client.GitHub.Repositories.GetContents(client.Ctx, owner, repo, manifestPath, nil)
+	if err == nil && contents == nil {
+		err = errors.New("não é um arquivo")
+	}
+	var content string
+	if err == nil {
+		content, err = contents.GetContent()
+	}
+	var parsed []*Dependency
+	if err == nil {
+		parsed, err = parser.parse(content)
+	}
+	if err != nil {
+		manifest.Error = err.Error()
+		return manifest, nil, nil
+	}
+
+	for _, dep := range parsed {
+		dep.Manifest = manifestPath
+		dep.Sources = []string{DependencySourceManifest}
+	}
+	manifest.Dependencies = len(parsed)
+
+	var includes []string
+	if parser.includes != nil {
+		for _, include := range parser.includes(content) {
+			// Caminhos fora do repositório não podem ser lidos
+			if included := path.Join(path.Dir(manifestPath), include); !strings.HasPrefix(included, "../") && included != ".." {
+				includes = append(includes, included)
+			}
+		}
+	}
+	return manifest, parsed, includes
+}
+
+// extractSBOM exporta o SBOM do dependency graph (indisponível com o
+// dependency graph desativado) e adiciona as versões resolvidas ao índice
+func extractSBOM(client *ghclient.Client, owner, repo string, deps *DependencyData, index map[string]*Dependency) error {
+	req, err := client.GitHub.NewRequest("GET", fmt.Sprintf("repos/%v/%v/dependency-graph/sbom", owner, repo), nil)
+	if err != nil {
+		return err
+	}
+	var document spdxDocument
+	if _, err := client.GitHub.Do(client.Ctx, req, &document); err != nil {
+		return err
+	}
+
+	deps.SBOMAvailable = true
+	deps.SBOMCreatedAt = document.SBOM.CreationInfo.Created
+
+	described := make(map[string]bool)
+	for _, id := range document.SBOM.DocumentDescribes {
+		described[id] = true
+	}
+	for _, pkg := range document.SBOM.Packages {
+		if described[pkg.SPDXID] {
+			continue // O próprio repositório
+		}
+
+		dep := &Dependency{Name: pkg.Name, Version: pkg.VersionInfo, Sources: []string{DependencySourceSBOM}}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				dep.PURL = ref.ReferenceLocator
+				if ecosystem, name, version, ok := ParsePURL(ref.ReferenceLocator); ok {
+					dep.Ecosystem, dep.Name = ecosystem, name
+					if version != "" {
+						dep.Version = version
+					}
+				}
+				break
+			}
+		}
+		for _, license := range []string{pkg.LicenseConcluded, pkg.LicenseDeclared} {
+			if license != "" && license != "NOASSERTION" {
+				dep.License = license
+				break
+			}
+		}
+		mergeDependency(index, dep)
+	}
+	return nil
+}
+
+// mergeDependency junta a dependência à já conhecida com o mesmo ecossistema
+// e nome: o manifesto define relação e escopo, o SBOM a versão resolvida
+func mergeDependency(index map[string]*Dependency, dep *Dependency) {
+	key := dep.Ecosystem + ":" + normalizedName(dep.Ecosystem, dep.Name)
+	existing, ok := index[key]
+	if !ok {
+		index[key] = dep
+		return
+	}
+
+	for _, source := range dep.Sources {
+		if !containsString(existing.Sources, source) {
+			existing.Sources = append(existing.Sources, source)
+		}
+	}
+	if dep.Relationship == DependencyDirect {
+		existing.Relationship = DependencyDirect
+	}
+	if existing.Relationship == "" {
+		existing.Relationship = dep.Relationship
+	}
+	if existing.Scope == "" || dep.Scope == ScopeRuntime {
+		existing.Scope = dep.Scope
+	}
+	if existing.Manifest == "" {
+		existing.Manifest = dep.Manifest
+	}
+	if dep.PURL != "" {
+		existing.PURL = dep.PURL
+	}
+	if dep.License != "" {
+		existing.License = dep.License
+	}
+	if containsString(dep.Sources, DependencySourceSBOM) && dep.Version != "" {
+		existing.Version = dep.Version
+	}
+}
+
+// normalizedName retorna o nome usado para identificar a dependência no
+// ecossistema: nomes PyPI seguem a PEP 503, os demais ignoram maiúsculas
+func normalizedName(ecosystem, name string) string {
+	if ecosystem == EcosystemPyPI {
+		return NormalizePyPIName(name)
+	}
+	return strings.ToLower(name)
+}
+
+// ParsePURL extrai ecossistema, nome e versão de um Package URL
+// (ex: "pkg:npm/%40babel/core@7.22.0" -> npm, "@babel/core", "7.22.0").
+// Em Maven o nome é "grupo:artefato".
+func ParsePURL(purl string) (ecosystem, name, version string, ok bool) {
+	rest, found := strings.CutPrefix(purl, "pkg:")
+	if !found {
+		return "", "", "", false
+	}
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+	ecosystem, rest, found = strings.Cut(rest, "/")
+	// A versão vem após o último "@" do nome; um "@" literal no namespace
+	// (ex: "pkg:npm/@babel/core@7.22.0") pertence ao nome
+	if idx := strings.LastIndex(rest, "@"); idx > strings.LastIndex(rest, "/") {
+		rest, version = rest[:idx], rest[idx+1:]
+	}
+	if !found || rest == "" {
+		return "", "", "", false
+	}
+
+	segments := strings.Split(rest, "/")
+	for i, segment := range segments {
+		if decoded, err := url.PathUnescape(segment); err == nil {
+			segments[i] = decoded
+		}
+	}
+	if decoded, err := url.PathUnescape(version); err == nil {
+		version = decoded
+	}
+
+	ecosystem = strings.ToLower(ecosystem)
+	if ecosystem == EcosystemMaven && len(segments) == 2 {
+		return ecosystem, segments[0] + ":" + segments[1], version, true
+	}
+	return ecosystem, strings.Join(segments, "/"), version, true
+}
+
+// BuildPURL monta o Package URL de uma dependência. Versões que não são
+// exatas (faixas como "^1.2.0" ou ">=2") ficam de fora, como pede a spec.
+func BuildPURL(ecosystem, name, version string) string {
+	if ecosystem == "" || name == "" {
+		return ""
+	}
+
+	var segments []string
+	switch ecosystem {
+	case EcosystemMaven:
+		segments = strings.SplitN(name, ":", 2)
+	case EcosystemPyPI:
+		segments = []string{NormalizePyPIName(name)}
+	default:
+		segments = strings.Split(name, "/")
+	}
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+	}
+
+	purl := "pkg:" + ecosystem + "/" + strings.Join(segments, "/")
+	if IsExactVersion(version) {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// IsExactVersion indica se a versão é fixa, e não uma faixa
+func IsExactVersion(version string) bool {
+	return version != "" && !strings.ContainsAny(version, "^~<>=*, |$") && !strings.EqualFold(version, "latest")
+}
+
+// containsString verifica se a lista contém o valor
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package extractor
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// StaleIssueDays define quantos dias sem atualização tornam uma issue aberta obsoleta
+const StaleIssueDays = 90
+
+// searchMaxWait limita a espera pela cota da Search API, que é renovada a
+// cada minuto; acima disso a extração usa a amostra de issues recentes
+const searchMaxWait = time.Minute
+
+// searchMu serializa as consultas à Search API do processo, que têm uma cota
+// própria (30 requisições/minuto autenticado) separada da cota principal
+var searchMu sync.Mutex
+
+// IssueCounts guarda as contagens totais de issues (sem PRs) obtidas pela
+// Search API, em vez da amostra de issues recentes
+type IssueCounts struct {
+	Open       int       `json:"open"`
+	Closed     int       `json:"closed"`
+	StaleOpen  int       `json:"stale_open"`
+	StaleDays  int       `json:"stale_days"`
+	Incomplete bool      `json:"incomplete_results"`
+	CountedAt  time.Time `json:"counted_at"`
+}
+
+// Total retorna o total de issues abertas e fechadas
+func (c *IssueCounts) Total() int {
+	return c.Open + c.Closed
+}
+
+// extractIssueCounts conta issues abertas, fechadas e obsoletas com três
+// consultas à Search API (uma por contagem, sem paginar os resultados)
+func extractIssueCounts(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	now := time.Now()
+	base := fmt.Sprintf("repo:%s/%s is:issue", owner, repo)
+	staleBefore := now.AddDate(0, 0, -StaleIssueDays).Format("2006-01-02")
+
+	counts := &IssueCounts{StaleDays: StaleIssueDays, CountedAt: now}
+	queries := []struct {
+		qualifiers string
+		target     *int
+	}{
+		{"is:open", &counts.Open},
+		{"is:closed", &counts.Closed},
+		{"is:open updated:<" + staleBefore, &counts.StaleOpen},
+	}
+
+	searchMu.Lock()
+	defer searchMu.Unlock()
+
+	if err := waitSearchBudget(client, len(queries)); err != nil {
+		return err
+	}
+	for _, query := range queries {
+		total, incomplete, err := searchIssueCount(client, base+" "+query.qualifiers)
+		if err != nil {
+			return err
+		}
+		*query.target = total
+		counts.Incomplete = counts.Incomplete || incomplete
+	}
+
+	data.IssueCounts = counts
+	return nil
+}
+
+// waitSearchBudget aguarda a renovação da cota da Search API quando ela não
+// comporta as consultas da extração. A consulta a /rate_limit não consome cota.
+func waitSearchBudget(client *ghclient.Client, calls int) error {
+	limits, _, err := client.GitHub.RateLimits(client.Ctx)
+	if err != nil {
+		return err
+	}
+	search := limits.GetSearch()
+	if search == nil || search.Remaining >= calls {
+		return nil
+	}
+
+	wait := time.Until(search.Reset.Time)
+	if wait > searchMaxWait {
+		return fmt.Errorf("cota da Search API esgotada (%d restantes) até %s",
+			search.Remaining, search.Reset.Format("15:04:05"))
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	log.Printf("⏳ Cota da Search API esgotada (%d restantes): aguardando %s", search.Remaining, wait.Round(time.Second))
+	select {
+	case <-client.Ctx.Done():
+		return client.Ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// searchIssueCount retorna o total de resultados de uma busca de issues
+func searchIssueCount(client *ghclient.Client, query string) (int, bool, error) {
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}}
+
+	result, _, err := client.GitHub.Search.Issues(client.Ctx, query, opts)
+	if err != nil {
+		return 0, false, err
+	}
+	return result.GetTotal(), result.GetIncompleteResults(), nil
+}
+
+// applyIssueCounts retorna uma cópia das contagens ajustada pela ação de um
+// evento de issue. wasStale indica que a issue estava aberta e obsoleta antes
+// do evento (conhecido apenas para issues da amostra recente): qualquer
+// evento a atualiza, então ela deixa de contar como obsoleta. Para as demais,
+// as issues obsoletas são limitadas às abertas até a próxima recontagem.
+func applyIssueCounts(counts *IssueCounts, action, state string, wasStale bool) *IssueCounts {
+	if counts == nil {
+		return nil
+	}
+
+	updated := *counts
+	switch action {
+	case "opened":
+		updated.Open++
+	case "closed":
+		updated.Open--
+		updated.Closed++
+	case "reopened":
+		updated.Closed--
+		updated.Open++
+	case "deleted", "transferred":
+		if state == "open" {
+			updated.Open--
+		} else {
+			updated.Closed--
+		}
+	default:
+		if !wasStale {
+			return counts
+		}
+	}
+
+	if wasStale {
+		updated.StaleOpen--
+	}
+	updated.StaleOpen = max(0, min(updated.StaleOpen, updated.Open))
+	return &updated
+}
@@ -0,0 +1,318 @@
+package extractor
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// manifestParser interpreta um tipo de manifesto; includes, quando definido,
+// lista outros arquivos referenciados pelo manifesto, relativos a ele
+type manifestParser struct {
+	ecosystem string
+	parse     func(content string) ([]*Dependency, error)
+	includes  func(content string) []string
+}
+
+// manifestParsers associa o nome do arquivo de manifesto ao parser correspondente
+var manifestParsers = map[string]manifestParser{
+	"go.mod":           {ecosystem: EcosystemGo, parse: parseGoMod},
+	"package.json":     {ecosystem: EcosystemNPM, parse: parsePackageJSON},
+	"requirements.txt": {ecosystem: EcosystemPyPI, parse: parseRequirements, includes: requirementIncludes},
+	"pom.xml":          {ecosystem: EcosystemMaven, parse: parsePomXML},
+}
+
+// manifestSkipDirs são diretórios de terceiros cujos manifestos não são lidos
+var manifestSkipDirs = map[string]bool{
+	"vendor": true, "node_modules": true, "third_party": true, "testdata": true,
+}
+
+// findManifests lista os manifestos conhecidos na árvore do repositório, fora
+// de diretórios de terceiros, dos mais rasos para os mais profundos
+func findManifests(tree *RepositoryTree) []string {
+	var manifests []string
+	for _, file := range tree.Files {
+		if _, ok := manifestParsers[path.Base(file.Path)]; !ok {
+			continue
+		}
+		skip := false
+		for _, segment := range strings.Split(path.Dir(file.Path), "/") {
+			if manifestSkipDirs[segment] {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			manifests = append(manifests, file.Path)
+		}
+	}
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return strings.Count(manifests[i], "/") < strings.Count(manifests[j], "/")
+	})
+	return manifests
+}
+
+// parseGoMod lê as diretivas require do go.mod; dependências marcadas com
+// "// indirect" são transitivas
+func parseGoMod(content string) ([]*Dependency, error) {
+	var deps []*Dependency
+	inBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "require (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inBlock:
+			continue
+		}
+
+		code, comment, _ := strings.Cut(line, "//")
+		fields := strings.Fields(code)
+		if len(fields) < 2 {
+			continue
+		}
+		relationship := DependencyDirect
+		if strings.TrimSpace(comment) == "indirect" {
+			relationship = DependencyIndirect
+		}
+		deps = append(deps, &Dependency{
+			Name:         fields[0],
+			Version:      fields[1],
+			Ecosystem:    EcosystemGo,
+			Relationship: relationship,
+			Scope:        ScopeRuntime,
+		})
+	}
+	return deps, nil
+}
+
+// parsePackageJSON lê dependências de runtime, de desenvolvimento, peer e
+// opcionais; todas são diretas (as transitivas ficam no lockfile)
+func parsePackageJSON(content string) ([]*Dependency, error) {
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	for _, group := range []struct {
+		scope string
+		deps  map[string]string
+	}{
+		{ScopeRuntime, manifest.Dependencies},
+		{ScopeDevelopment, manifest.DevDependencies},
+		{ScopePeer, manifest.PeerDependencies},
+		{ScopeOptional, manifest.OptionalDependencies},
+	} {
+		names := make([]string, 0, len(group.deps))
+		for name := range group.deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			deps = append(deps, &Dependency{
+				Name:         name,
+				Version:      group.deps[name],
+				Ecosystem:    EcosystemNPM,
+				Relationship: DependencyDirect,
+				Scope:        group.scope,
+			})
+		}
+	}
+	return deps, nil
+}
+
+// requirementPattern separa nome, extras e especificação de versão de uma
+// linha do requirements.txt (ex: "requests[socks]>=2.31,<3")
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(.*)$`)
+
+// pypiNameSeparators são as sequências de separadores que a PEP 503 reduz a "-"
+var pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// NormalizePyPIName normaliza o nome de um pacote Python como a PEP 503
+// (ex: "Foo_Bar" e "foo.bar" -> "foo-bar"), usada também no purl
+func NormalizePyPIName(name string) string {
+	return strings.ToLower(pypiNameSeparators.ReplaceAllString(name, "-"))
+}
+
+// requirementLines retorna as linhas lógicas de um requirements.txt: linhas
+// terminadas em "\" continuam na seguinte e comentários são removidos
+func requirementLines(content string) ([]string, error) {
+	var lines []string
+	var continued strings.Builder
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasSuffix(line, "\\") && !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continued.WriteString(strings.TrimSuffix(line, "\\"))
+			continue
+		}
+		continued.WriteString(line)
+		line = continued.String()
+		continued.Reset()
+
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if rest := strings.TrimSpace(continued.String()); rest != "" {
+		lines = append(lines, rest)
+	}
+	return lines, scanner.Err()
+}
+
+// requirementIncludes lista os arquivos incluídos com -r/--requirement;
+// URLs não são seguidas
+func requirementIncludes(content string) []string {
+	lines, _ := requirementLines(content)
+
+	var includes []string
+	for _, line := range lines {
+		var file string
+		switch {
+		case strings.HasPrefix(line, "--requirement"):
+			file = strings.TrimLeft(strings.TrimPrefix(line, "--requirement"), " =")
+		case strings.HasPrefix(line, "-r"):
+			file = strings.TrimSpace(strings.TrimPrefix(line, "-r"))
+		default:
+			continue
+		}
+		if file != "" && !strings.Contains(file, "://") {
+			includes = append(includes, file)
+		}
+	}
+	return includes
+}
+
+// parseRequirements lê um requirements.txt, ignorando comentários, opções
+// (-e, --index-url...) e marcadores de ambiente. Os arquivos incluídos com -r
+// são lidos como manifestos próprios (ver requirementIncludes).
+func parseRequirements(content string) ([]*Dependency, error) {
+	var deps []*Dependency
+
+	lines, err := requirementLines(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "-") {
+			continue
+		}
+		line, _, _ = strings.Cut(line, ";")
+		// Opções por requisito (ex: --hash, comuns em linhas continuadas)
+		if i := strings.Index(line, " -"); i >= 0 {
+			line = line[:i]
+		}
+
+		match := requirementPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		version := strings.ReplaceAll(strings.TrimSpace(match[3]), " ", "")
+		version = strings.TrimPrefix(version, "==")
+		deps = append(deps, &Dependency{
+			Name:         match[1],
+			Version:      version,
+			Ecosystem:    EcosystemPyPI,
+			Relationship: DependencyDirect,
+			Scope:        ScopeRuntime,
+		})
+	}
+	return deps, nil
+}
+
+// pomProject é o subconjunto do pom.xml usado na extração
+type pomProject struct {
+	Version string `xml:"version"`
+	Parent  struct {
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies []struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+		Scope      string `xml:"scope"`
+		Optional   bool   `xml:"optional"`
+	} `xml:"dependencies>dependency"`
+}
+
+// pomPropertyPattern encontra referências ${propriedade} no pom.xml
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// parsePomXML lê as dependências declaradas no pom.xml, resolvendo as
+// propriedades definidas no próprio arquivo; versões herdadas do parent ou de
+// dependencyManagement ficam em branco
+func parsePomXML(content string) ([]*Dependency, error) {
+	var project pomProject
+	if err := xml.Unmarshal([]byte(content), &project); err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{
+		"project.version":        project.Version,
+		"project.parent.version": project.Parent.Version,
+	}
+	for _, entry := range project.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	resolve := func(value string) string {
+		return pomPropertyPattern.ReplaceAllStringFunc(strings.TrimSpace(value), func(ref string) string {
+			if resolved, ok := properties[ref[2:len(ref)-1]]; ok && resolved != "" {
+				return resolved
+			}
+			return ref
+		})
+	}
+
+	var deps []*Dependency
+	for _, dep := range project.Dependencies {
+		scope := ScopeRuntime
+		switch {
+		case dep.Optional:
+			scope = ScopeOptional
+		case dep.Scope == "test":
+			scope = ScopeDevelopment
+		case dep.Scope == "provided":
+			scope = ScopePeer
+		}
+		deps = append(deps, &Dependency{
+			Name:         fmt.Sprintf("%s:%s", resolve(dep.GroupID), resolve(dep.ArtifactID)),
+			Version:      resolve(dep.Version),
+			Ecosystem:    EcosystemMaven,
+			Relationship: DependencyDirect,
+			Scope:        scope,
+		})
+	}
+	return deps, nil
+}
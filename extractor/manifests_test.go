@@ -0,0 +1,298 @@
+package extractor
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// dependencyStrings resume as dependências como "nome versão relação escopo"
+func dependencyStrings(deps []*Dependency) []string {
+	var out []string
+	for _, dep := range deps {
+		out = append(out, fmt.Sprintf("%s %s %s %s", dep.Name, dep.Version, dep.Relationship, dep.Scope))
+	}
+	return out
+}
+
+func TestParseGoMod(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "bloco require com dependências indiretas",
+			content: "module example.com/app\n\ngo 1.21\n\nrequire (\n" +
+				"\tgithub.com/google/go-github/v57 v57.0.0\n" +
+				"\tgolang.org/x/oauth2 v0.15.0 // indirect\n" +
+				"\tgithub.com/a/b v1.0.0 // comentário\n)\n",
+			want: []string{
+				"github.com/google/go-github/v57 v57.0.0 direct runtime",
+				"golang.org/x/oauth2 v0.15.0 indirect runtime",
+				"github.com/a/b v1.0.0 direct runtime",
+			},
+		},
+		{
+			name:    "require de uma linha",
+			content: "module x\n\nrequire github.com/a/b v1.2.3\nrequire github.com/c/d v0.1.0 // indirect\n",
+			want:    []string{"github.com/a/b v1.2.3 direct runtime", "github.com/c/d v0.1.0 indirect runtime"},
+		},
+		{
+			name:    "replace e exclude são ignorados",
+			content: "module x\n\nreplace github.com/a/b => ../b\nexclude github.com/c/d v0.1.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, err := parseGoMod(tt.content)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got := dependencyStrings(deps); !slices.Equal(got, tt.want) {
+				t.Errorf("dependências = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "grupos de dependências viram escopos",
+			content: `{"dependencies": {"react": "^18.2.0", "axios": "1.6.0"},
+				"devDependencies": {"jest": "~29.0.0"},
+				"peerDependencies": {"react-dom": ">=18"},
+				"optionalDependencies": {"fsevents": "2.3.3"}}`,
+			want: []string{
+				"axios 1.6.0 direct runtime",
+				"react ^18.2.0 direct runtime",
+				"jest ~29.0.0 direct development",
+				"react-dom >=18 direct peer",
+				"fsevents 2.3.3 direct optional",
+			},
+		},
+		{name: "sem dependências", content: `{"name": "app"}`},
+		{name: "JSON inválido", content: `{"dependencies": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, err := parsePackageJSON(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erro = %v, esperado erro: %v", err, tt.wantErr)
+			}
+			if got := dependencyStrings(deps); !slices.Equal(got, tt.want) {
+				t.Errorf("dependências = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequirements(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "versões, extras e faixas",
+			content: "requests==2.31.0\nurllib3[socks] >= 1.26, < 3\nDjango\nnumpy == 1.26.0\n",
+			want: []string{
+				"requests 2.31.0 direct runtime",
+				"urllib3 >=1.26,<3 direct runtime",
+				"Django  direct runtime",
+				"numpy 1.26.0 direct runtime",
+			},
+		},
+		{
+			name:    "comentários e marcadores de ambiente",
+			content: "# dependências\nflask==3.0.0  # web\ntomli>=2.0; python_version < \"3.11\"\n",
+			want:    []string{"flask 3.0.0 direct runtime", "tomli >=2.0 direct runtime"},
+		},
+		{
+			name:    "opções e includes são ignorados",
+			content: "--index-url https://pypi.org/simple\n-r dev.txt\n-e git+https://github.com/a/b.git#egg=b\nsix==1.16.0\n",
+			want:    []string{"six 1.16.0 direct runtime"},
+		},
+		{
+			name:    "linhas continuadas com hashes",
+			content: "cryptography==41.0.7 \\\n    --hash=sha256:abc \\\n    --hash=sha256:def\nidna==3.6\n",
+			want:    []string{"cryptography 41.0.7 direct runtime", "idna 3.6 direct runtime"},
+		},
+		{
+			name:    "continuação na última linha",
+			content: "attrs==23.1.0 \\",
+			want:    []string{"attrs 23.1.0 direct runtime"},
+		},
+		{
+			name:    "comentário terminado em barra não continua",
+			content: "# ver C:\\\nsix==1.16.0\n",
+			want:    []string{"six 1.16.0 direct runtime"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, err := parseRequirements(tt.content)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got := dependencyStrings(deps); !slices.Equal(got, tt.want) {
+				t.Errorf("dependências = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirementIncludes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "formas curta e longa", content: "-r base.txt\n-rdev.txt\n--requirement test.txt\n--requirement=docs.txt\n", want: []string{"base.txt", "dev.txt", "test.txt", "docs.txt"}},
+		{name: "URLs não são seguidas", content: "-r https://example.com/reqs.txt\n-r local.txt\n", want: []string{"local.txt"}},
+		{name: "comentários e constraints", content: "# -r old.txt\n-c constraints.txt\n-r ../shared.txt # comum\n", want: []string{"../shared.txt"}},
+		{name: "sem includes", content: "requests==2.31.0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requirementIncludes(tt.content); !slices.Equal(got, tt.want) {
+				t.Errorf("requirementIncludes = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePomXML(t *testing.T) {
+	content := `<project>
+  <version>2.0.0</version>
+  <parent><version>1.5.0</version></parent>
+  <properties><junit.version>5.10.0</junit.version></properties>
+  <dependencies>
+    <dependency><groupId>org.junit</groupId><artifactId>junit</artifactId><version>${junit.version}</version><scope>test</scope></dependency>
+    <dependency><groupId>com.acme</groupId><artifactId>core</artifactId><version>${project.version}</version></dependency>
+    <dependency><groupId>com.acme</groupId><artifactId>parent-lib</artifactId><version>${project.parent.version}</version><scope>provided</scope></dependency>
+    <dependency><groupId>com.acme</groupId><artifactId>extra</artifactId><version>${missing}</version><optional>true</optional></dependency>
+    <dependency><groupId>com.acme</groupId><artifactId>managed</artifactId></dependency>
+  </dependencies>
+</project>`
+
+	deps, err := parsePomXML(content)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	want := []string{
+		"org.junit:junit 5.10.0 direct development",
+		"com.acme:core 2.0.0 direct runtime",
+		"com.acme:parent-lib 1.5.0 direct peer",
+		"com.acme:extra ${missing} direct optional",
+		"com.acme:managed  direct runtime",
+	}
+	if got := dependencyStrings(deps); !slices.Equal(got, want) {
+		t.Errorf("dependências = %q, esperado %q", got, want)
+	}
+
+	if _, err := parsePomXML("<project>"); err == nil {
+		t.Error("pom.xml inválido deveria retornar erro")
+	}
+}
+
+func TestNormalizePyPIName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"requests", "requests"},
+		{"Foo_Bar", "foo-bar"},
+		{"foo.bar", "foo-bar"},
+		{"Foo-._Bar", "foo-bar"},
+		{"zope.interface", "zope-interface"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePyPIName(tt.name); got != tt.want {
+			t.Errorf("NormalizePyPIName(%q) = %q, esperado %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildPURL(t *testing.T) {
+	tests := []struct {
+		ecosystem, name, version string
+		want                     string
+	}{
+		{EcosystemGo, "github.com/google/go-github/v57", "v57.0.0", "pkg:golang/github.com/google/go-github/v57@v57.0.0"},
+		{EcosystemNPM, "@types/node", "20.1.0", "pkg:npm/%40types/node@20.1.0"},
+		{EcosystemNPM, "react", "^18.2.0", "pkg:npm/react"},
+		{EcosystemNPM, "react", "latest", "pkg:npm/react"},
+		{EcosystemPyPI, "Foo_Bar", "1.0", "pkg:pypi/foo-bar@1.0"},
+		{EcosystemPyPI, "urllib3", ">=1.26,<3", "pkg:pypi/urllib3"},
+		{EcosystemMaven, "org.junit:junit", "5.10.0", "pkg:maven/org.junit/junit@5.10.0"},
+		{EcosystemMaven, "com.acme:core", "", "pkg:maven/com.acme/core"},
+		{"", "react", "1.0.0", ""},
+		{EcosystemNPM, "", "1.0.0", ""},
+	}
+
+	for _, tt := range tests {
+		if got := BuildPURL(tt.ecosystem, tt.name, tt.version); got != tt.want {
+			t.Errorf("BuildPURL(%q, %q, %q) = %q, esperado %q", tt.ecosystem, tt.name, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParsePURL(t *testing.T) {
+	tests := []struct {
+		purl                     string
+		ecosystem, name, version string
+		ok                       bool
+	}{
+		{"pkg:npm/%40types/node@20.1.0", EcosystemNPM, "@types/node", "20.1.0", true},
+		{"pkg:npm/@scope/name@1.0.0", EcosystemNPM, "@scope/name", "1.0.0", true},
+		{"pkg:npm/@scope/name", EcosystemNPM, "@scope/name", "", true},
+		{"pkg:npm/name@1.0.0%40beta", EcosystemNPM, "name", "1.0.0@beta", true},
+		{"pkg:golang/github.com/a/b@v1.2.3", EcosystemGo, "github.com/a/b", "v1.2.3", true},
+		{"pkg:maven/org.junit/junit@5.10.0?type=jar", EcosystemMaven, "org.junit:junit", "5.10.0", true},
+		{"pkg:PyPI/requests@2.31.0#src", EcosystemPyPI, "requests", "2.31.0", true},
+		{"pkg:npm/react", EcosystemNPM, "react", "", true},
+		{"pkg:npm", "", "", "", false},
+		{"pkg:npm/", "", "", "", false},
+		{"npm/react@18.2.0", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		ecosystem, name, version, ok := ParsePURL(tt.purl)
+		if ecosystem != tt.ecosystem || name != tt.name || version != tt.version || ok != tt.ok {
+			t.Errorf("ParsePURL(%q) = (%q, %q, %q, %v), esperado (%q, %q, %q, %v)",
+				tt.purl, ecosystem, name, version, ok, tt.ecosystem, tt.name, tt.version, tt.ok)
+		}
+	}
+}
+
+func TestFindManifests(t *testing.T) {
+	tree := &RepositoryTree{}
+	for _, path := range []string{
+		"web/package.json",
+		"go.mod",
+		"vendor/github.com/a/b/go.mod",
+		"web/node_modules/react/package.json",
+		"services/api/requirements.txt",
+		"README.md",
+		"java/pom.xml",
+	} {
+		tree.Files = append(tree.Files, &TreeFile{Path: path})
+	}
+
+	want := []string{"go.mod", "web/package.json", "java/pom.xml", "services/api/requirements.txt"}
+	if got := findManifests(tree); !slices.Equal(got, want) {
+		t.Errorf("findManifests = %q, esperado %q", got, want)
+	}
+}
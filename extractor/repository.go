@@ -32,9 +32,18 @@ type RepositoryData struct {
 	// Colaboradores
 	Contributors []*Contributor `json:"contributors"`
 	
+	// Commits semanais por colaborador (endpoint de estatísticas)
+	ContributorActivity []*ContributorActivity `json:"contributor_activity,omitempty"`
+	
 	// Issues recentes
 	RecentIssues []*IssueData `json:"recent_issues"`
 	
+	// Contagens totais de issues (Search API)
+	IssueCounts *IssueCounts `json:"issue_counts,omitempty"`
+	
+	// Motivo de as contagens totais não estarem disponíveis (usa-se a amostra)
+	IssueCountsError string `json:"issue_counts_error,omitempty"`
+	
 	// Pull Requests recentes
 	RecentPRs []*PullRequestData `json:"recent_prs"`
 	
@@ -44,9 +53,34 @@ type RepositoryData struct {
 	// Commits recentes
 	RecentCommits []*CommitData `json:"recent_commits"`
 	
+	// Histórico de commits da análise das mensagens (RecentCommits são os
+	// primeiros)
+	CommitHistory []*CommitData `json:"commit_history,omitempty"`
+	
 	// Eventos recentes
 	RecentEvents []*EventData `json:"recent_events"`
 	
+	// Estatísticas do repositório (code frequency, atividade, punch card)
+	Stats *RepositoryStats `json:"stats,omitempty"`
+	
+	// Endpoints de estatísticas que o GitHub ainda estava calculando
+	StatsPending []string `json:"stats_pending,omitempty"`
+	
+	// Arquivos de comunidade (README, CONTRIBUTING, templates...)
+	Community *CommunityProfile `json:"community,omitempty"`
+	
+	// Árvore de arquivos do branch padrão
+	Tree *RepositoryTree `json:"tree,omitempty"`
+	
+	// CODEOWNERS interpretado e validado
+	CodeOwners *CodeOwnersFile `json:"codeowners,omitempty"`
+	
+	// Dependências (SBOM do dependency graph e manifestos)
+	Dependencies *DependencyData `json:"dependencies,omitempty"`
+	
+	// Alertas de segurança abertos (Dependabot, code scanning, secret scanning)
+	Security *SecurityData `json:"security,omitempty"`
+	
 	// Rate limit info
 	RateLimit *RateLimitData `json:"rate_limit"`
 	
@@ -127,13 +161,22 @@ type PullRequestData struct {
 }
 
 type ReleaseData struct {
-	TagName     string    `json:"tag_name"`
-	Name        string    `json:"name"`
-	CreatedAt   time.Time `json:"created_at"`
-	PublishedAt time.Time `json:"published_at"`
-	Prerelease  bool      `json:"prerelease"`
-	Draft       bool      `json:"draft"`
-	Author      string    `json:"author"`
+	TagName     string          `json:"tag_name"`
+	Name        string          `json:"name"`
+	CreatedAt   time.Time       `json:"created_at"`
+	PublishedAt time.Time       `json:"published_at"`
+	Prerelease  bool            `json:"prerelease"`
+	Draft       bool            `json:"draft"`
+	Author      string          `json:"author"`
+	Assets      []*ReleaseAsset `json:"assets,omitempty"`
+}
+
+// ReleaseAsset é um arquivo anexado a um release
+type ReleaseAsset struct {
+	Name          string `json:"name"`
+	Size          int    `json:"size"`
+	ContentType   string `json:"content_type"`
+	DownloadCount int    `json:"download_count"`
 }
 
 type CommitData struct {
@@ -142,6 +185,12 @@ type CommitData struct {
 	Author    string    `json:"author"`
 	CreatedAt time.Time `json:"created_at"`
 	URL       string    `json:"url"`
+	// Parents é o número de pais (mais de um em commits de merge)
+	Parents  int  `json:"parents"`
+	Verified bool `json:"verified"`
+	// Verification é o motivo da verificação da assinatura ("valid",
+	// "unsigned", "unknown_key"...); vazio quando não informado
+	Verification string `json:"verification,omitempty"`
 }
 
 type EventData struct {
@@ -184,7 +233,7 @@ func ExtractRepositoryData(client *ghclient.Client, owner, repo string) (*Reposi
 	// 1. Informações básicas do repositório
 	log.Println("📋 Extraindo informações básicas...")
 	if err := extractBasicInfo(client, owner, repo, data); err != nil {
-		return nil, fmt.Errorf("erro ao extrair informações básicas: %v", err)
+		return nil, fmt.Errorf("erro ao extrair informações básicas: %w", err)
 	}
 
 	// 2. Linguagens do repositório
@@ -199,12 +248,25 @@ func ExtractRepositoryData(client *ghclient.Client, owner, repo string) (*Reposi
 		log.Printf("⚠️ Erro ao extrair colaboradores: %v", err)
 	}
 
+	// 3.1. Estatísticas (atividade dos colaboradores, commits, código e horários)
+	log.Println("📊 Extraindo estatísticas do repositório...")
+	if err := extractStats(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao extrair estatísticas: %v", err)
+	}
+
 	// 4. Issues recentes
 	log.Println("🎯 Extraindo issues recentes...")
 	if err := extractRecentIssues(client, owner, repo, data); err != nil {
 		log.Printf("⚠️ Erro ao extrair issues: %v", err)
 	}
 
+	// 4.1. Contagens totais de issues
+	log.Println("🔢 Contando issues via Search API...")
+	if err := extractIssueCounts(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao contar issues (usando a amostra recente): %v", err)
+		data.IssueCountsError = err.Error()
+	}
+
 	// 5. Pull Requests recentes
 	log.Println("🔄 Extraindo pull requests recentes...")
 	if err := extractRecentPRs(client, owner, repo, data); err != nil {
@@ -229,6 +291,36 @@ func ExtractRepositoryData(client *ghclient.Client, owner, repo string) (*Reposi
 		log.Printf("⚠️ Erro ao extrair eventos: %v", err)
 	}
 
+	// 8.2. Arquivos de comunidade
+	log.Println("🤝 Verificando arquivos de comunidade...")
+	if err := extractCommunityProfile(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao verificar arquivos de comunidade: %v", err)
+	}
+
+	// 8.3. Árvore de arquivos
+	log.Println("🌳 Extraindo árvore de arquivos...")
+	if err := extractTree(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao extrair árvore de arquivos: %v", err)
+	}
+
+	// 8.4. CODEOWNERS
+	log.Println("🛡️ Verificando CODEOWNERS...")
+	if err := extractCodeOwners(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao verificar CODEOWNERS: %v", err)
+	}
+
+	// 8.5. Dependências
+	log.Println("📦 Extraindo dependências (SBOM e manifestos)...")
+	if err := extractDependencies(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao extrair dependências: %v", err)
+	}
+
+	// 8.6. Alertas de segurança
+	log.Println("🔒 Extraindo alertas de segurança...")
+	if err := extractSecurityAlerts(client, owner, repo, data); err != nil {
+		log.Printf("⚠️ Erro ao extrair alertas de segurança: %v", err)
+	}
+
 	// 9. Rate limit
 	log.Println("📊 Verificando rate limits...")
 	if err := extractRateLimit(client, data); err != nil {
@@ -351,27 +443,32 @@ func extractRecentIssues(client *ghclient.Client, owner, repo string, data *Repo
 	data.RecentIssues = make([]*IssueData, 0)
 	for _, issue := range issues {
 		if issue.PullRequestLinks == nil { // Apenas issues, não PRs
-			labels := make([]string, len(issue.Labels))
-			for i, label := range issue.Labels {
-				labels[i] = label.GetName()
-			}
-
-			data.RecentIssues = append(data.RecentIssues, &IssueData{
-				Number:    issue.GetNumber(),
-				Title:     issue.GetTitle(),
-				State:     issue.GetState(),
-				Author:    issue.GetUser().GetLogin(),
-				CreatedAt: issue.GetCreatedAt().Time,
-				UpdatedAt: issue.GetUpdatedAt().Time,
-				Labels:    labels,
-				Comments:  issue.GetComments(),
-			})
+			data.RecentIssues = append(data.RecentIssues, newIssueData(issue))
 		}
 	}
 
 	return nil
 }
 
+// newIssueData converte uma issue da API no formato extraído
+func newIssueData(issue *github.Issue) *IssueData {
+	labels := make([]string, len(issue.Labels))
+	for i, label := range issue.Labels {
+		labels[i] = label.GetName()
+	}
+
+	return &IssueData{
+		Number:    issue.GetNumber(),
+		Title:     issue.GetTitle(),
+		State:     issue.GetState(),
+		Author:    issue.GetUser().GetLogin(),
+		CreatedAt: issue.GetCreatedAt().Time,
+		UpdatedAt: issue.GetUpdatedAt().Time,
+		Labels:    labels,
+		Comments:  issue.GetComments(),
+	}
+}
+
 func extractRecentPRs(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
 	opts := &github.PullRequestListOptions{
 		State:       "all",
@@ -387,23 +484,32 @@ func extractRecentPRs(client *ghclient.Client, owner, repo string, data *Reposit
 
 	data.RecentPRs = make([]*PullRequestData, len(prs))
 	for i, pr := range prs {
-		data.RecentPRs[i] = &PullRequestData{
-			Number:    pr.GetNumber(),
-			Title:     pr.GetTitle(),
-			State:     pr.GetState(),
-			Author:    pr.GetUser().GetLogin(),
-			CreatedAt: pr.GetCreatedAt().Time,
-			UpdatedAt: pr.GetUpdatedAt().Time,
-			Merged:    pr.GetMerged(),
-			Draft:     pr.GetDraft(),
-		}
+		data.RecentPRs[i] = newPullRequestData(pr)
 	}
 
 	return nil
 }
 
+// newPullRequestData converte um pull request da API no formato extraído
+func newPullRequestData(pr *github.PullRequest) *PullRequestData {
+	return &PullRequestData{
+		Number:    pr.GetNumber(),
+		Title:     pr.GetTitle(),
+		State:     pr.GetState(),
+		Author:    pr.GetUser().GetLogin(),
+		CreatedAt: pr.GetCreatedAt().Time,
+		UpdatedAt: pr.GetUpdatedAt().Time,
+		Merged:    pr.GetMerged(),
+		Draft:     pr.GetDraft(),
+	}
+}
+
+// ReleaseHistorySize é o número de releases extraídos: o suficiente para a
+// análise de cadência e versionamento em uma única requisição
+const ReleaseHistorySize = 100
+
 func extractReleases(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
-	opts := &github.ListOptions{PerPage: 10}
+	opts := &github.ListOptions{PerPage: ReleaseHistorySize}
 
 	releases, _, err := client.GitHub.Repositories.ListReleases(client.Ctx, owner, repo, opts)
 	if err != nil {
@@ -412,23 +518,45 @@ func extractReleases(client *ghclient.Client, owner, repo string, data *Reposito
 
 	data.Releases = make([]*ReleaseData, len(releases))
 	for i, release := range releases {
-		data.Releases[i] = &ReleaseData{
-			TagName:     release.GetTagName(),
-			Name:        release.GetName(),
-			CreatedAt:   release.GetCreatedAt().Time,
-			PublishedAt: release.GetPublishedAt().Time,
-			Prerelease:  release.GetPrerelease(),
-			Draft:       release.GetDraft(),
-			Author:      release.GetAuthor().GetLogin(),
-		}
+		data.Releases[i] = newReleaseData(release)
 	}
 
 	return nil
 }
 
+// newReleaseData converte um release da API no formato extraído
+func newReleaseData(release *github.RepositoryRelease) *ReleaseData {
+	data := &ReleaseData{
+		TagName:     release.GetTagName(),
+		Name:        release.GetName(),
+		CreatedAt:   release.GetCreatedAt().Time,
+		PublishedAt: release.GetPublishedAt().Time,
+		Prerelease:  release.GetPrerelease(),
+		Draft:       release.GetDraft(),
+		Author:      release.GetAuthor().GetLogin(),
+	}
+	for _, asset := range release.Assets {
+		data.Assets = append(data.Assets, &ReleaseAsset{
+			Name:          asset.GetName(),
+			Size:          asset.GetSize(),
+			ContentType:   asset.GetContentType(),
+			DownloadCount: asset.GetDownloadCount(),
+		})
+	}
+	return data
+}
+
+// RecentCommitsSize é o número de commits em RecentCommits, base da atividade,
+// dos relatórios e das exportações
+const RecentCommitsSize = 10
+
+// CommitHistorySize é o número de commits extraídos para a análise das
+// mensagens (CommitHistory); a mesma requisição preenche RecentCommits
+const CommitHistorySize = 100
+
 func extractRecentCommits(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
 	opts := &github.CommitsListOptions{
-		ListOptions: github.ListOptions{PerPage: 10},
+		ListOptions: github.ListOptions{PerPage: CommitHistorySize},
 	}
 
 	commits, _, err := client.GitHub.Repositories.ListCommits(client.Ctx, owner, repo, opts)
@@ -436,16 +564,20 @@ func extractRecentCommits(client *ghclient.Client, owner, repo string, data *Rep
 		return err
 	}
 
-	data.RecentCommits = make([]*CommitData, len(commits))
+	data.CommitHistory = make([]*CommitData, len(commits))
 	for i, commit := range commits {
-		data.RecentCommits[i] = &CommitData{
-			SHA:       commit.GetSHA(),
-			Message:   commit.GetCommit().GetMessage(),
-			Author:    commit.GetCommit().GetAuthor().GetName(),
-			CreatedAt: commit.GetCommit().GetAuthor().GetDate().Time,
-			URL:       commit.GetHTMLURL(),
+		data.CommitHistory[i] = &CommitData{
+			SHA:          commit.GetSHA(),
+			Message:      commit.GetCommit().GetMessage(),
+			Author:       commit.GetCommit().GetAuthor().GetName(),
+			CreatedAt:    commit.GetCommit().GetAuthor().GetDate().Time,
+			URL:          commit.GetHTMLURL(),
+			Parents:      len(commit.Parents),
+			Verified:     commit.GetCommit().GetVerification().GetVerified(),
+			Verification: commit.GetCommit().GetVerification().GetReason(),
 		}
 	}
+	data.RecentCommits = data.CommitHistory[:min(len(data.CommitHistory), RecentCommitsSize)]
 
 	return nil
 }
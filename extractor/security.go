@@ -0,0 +1,277 @@
+package extractor
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// securityMaxPages limita as páginas (de 100 alertas) lidas por recurso
+const securityMaxPages = 5
+
+// Recursos de segurança consultados
+const (
+	SecurityDependabot     = "dependabot"
+	SecurityCodeScanning   = "code_scanning"
+	SecuritySecretScanning = "secret_scanning"
+)
+
+// SecurityFeatureOrder define a ordem de exibição dos recursos de segurança
+var SecurityFeatureOrder = []string{SecurityDependabot, SecurityCodeScanning, SecuritySecretScanning}
+
+// Situação de um recurso de segurança
+const (
+	SecurityEnabled = "enabled"
+	// SecurityDisabled indica recurso desativado ou sem análise configurada
+	SecurityDisabled = "disabled"
+	// SecurityForbidden indica token sem escopo (security_events) ou sem
+	// permissão de administração no repositório
+	SecurityForbidden = "forbidden"
+	SecurityError     = "error"
+)
+
+// Severidades normalizadas dos alertas, da mais grave para a menos grave
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// SeverityOrder lista as severidades da mais grave para a menos grave
+var SeverityOrder = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow}
+
+// SecurityData reúne os alertas abertos de Dependabot, code scanning e secret
+// scanning, com a situação de cada recurso
+type SecurityData struct {
+	Features map[string]*SecurityFeature `json:"features"`
+	Alerts   []*SecurityAlert            `json:"alerts"`
+}
+
+// SecurityFeature indica se os alertas de um recurso puderam ser lidos
+type SecurityFeature struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	// Truncated indica que havia mais alertas do que o limite de páginas
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// SecurityAlert é um alerta de segurança aberto
+type SecurityAlert struct {
+	Feature  string `json:"feature"`
+	Number   int    `json:"number"`
+	Severity string `json:"severity"`
+	Title    string `json:"title"`
+	// Location é o pacote (Dependabot), o arquivo (code scanning) ou o tipo
+	// de segredo (secret scanning)
+	Location  string    `json:"location,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+}
+
+// extractSecurityAlerts lista os alertas abertos dos três recursos. Cada um é
+// independente: 404 e 403 viram a situação do recurso, não erro da extração.
+func extractSecurityAlerts(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	security := &SecurityData{Features: make(map[string]*SecurityFeature)}
+	var errs []error
+
+	for _, feature := range SecurityFeatureOrder {
+		var (
+			alerts    []*SecurityAlert
+			truncated bool
+			err       error
+		)
+		switch feature {
+		case SecurityDependabot:
+			alerts, truncated, err = listDependabotAlerts(client, owner, repo)
+		case SecurityCodeScanning:
+			alerts, truncated, err = listCodeScanningAlerts(client, owner, repo)
+		case SecuritySecretScanning:
+			alerts, truncated, err = listSecretScanningAlerts(client, owner, repo)
+		}
+
+		status := securityStatus(err)
+		security.Features[feature] = &SecurityFeature{Status: status, Truncated: truncated}
+		if err != nil {
+			security.Features[feature].Message = securityMessage(err)
+			if status == SecurityError {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		security.Alerts = append(security.Alerts, alerts...)
+	}
+
+	data.Security = security
+	return errors.Join(errs...)
+}
+
+// listDependabotAlerts lista os alertas abertos do Dependabot (paginação por cursor)
+func listDependabotAlerts(client *ghclient.Client, owner, repo string) ([]*SecurityAlert, bool, error) {
+	opts := &github.ListAlertsOptions{
+		State:             github.String("open"),
+		ListCursorOptions: github.ListCursorOptions{First: 100},
+	}
+
+	var alerts []*SecurityAlert
+	for page := 0; page < securityMaxPages; page++ {
+		items, resp, err := client.GitHub.Dependabot.ListRepoAlerts(client.Ctx, owner, repo, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, item := range items {
+			advisory := item.GetSecurityAdvisory()
+			title := advisory.GetSummary()
+			if id := advisory.GetGHSAID(); id != "" {
+				title = id + ": " + title
+			}
+			alerts = append(alerts, &SecurityAlert{
+				Feature:   SecurityDependabot,
+				Number:    item.GetNumber(),
+				Severity:  normalizeSeverity(advisory.GetSeverity()),
+				Title:     title,
+				Location:  item.GetDependency().GetPackage().GetName(),
+				CreatedAt: item.GetCreatedAt().Time,
+				URL:       item.GetHTMLURL(),
+			})
+		}
+		if resp.After == "" {
+			return alerts, false, nil
+		}
+		opts.ListCursorOptions.After = resp.After
+	}
+	return alerts, true, nil
+}
+
+// listCodeScanningAlerts lista os alertas abertos de code scanning
+func listCodeScanningAlerts(client *ghclient.Client, owner, repo string) ([]*SecurityAlert, bool, error) {
+	opts := &github.AlertListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+
+	var alerts []*SecurityAlert
+	for page := 0; page < securityMaxPages; page++ {
+		items, resp, err := client.GitHub.CodeScanning.ListAlertsForRepo(client.Ctx, owner, repo, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, item := range items {
+			rule := item.GetRule()
+			// security_severity_level só existe em regras de segurança; as demais
+			// têm apenas a severidade da regra (error, warning, note)
+			severity := rule.GetSecuritySeverityLevel()
+			if severity == "" {
+				severity = rule.GetSeverity()
+			}
+			title := rule.GetDescription()
+			if title == "" {
+				title = rule.GetID()
+			}
+			alerts = append(alerts, &SecurityAlert{
+				Feature:   SecurityCodeScanning,
+				Number:    item.GetNumber(),
+				Severity:  normalizeSeverity(severity),
+				Title:     title,
+				Location:  item.GetMostRecentInstance().GetLocation().GetPath(),
+				CreatedAt: item.GetCreatedAt().Time,
+				URL:       item.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return alerts, false, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return alerts, true, nil
+}
+
+// listSecretScanningAlerts lista os alertas abertos de secret scanning. A API
+// não informa severidade: segredos expostos são tratados como críticos.
+func listSecretScanningAlerts(client *ghclient.Client, owner, repo string) ([]*SecurityAlert, bool, error) {
+	opts := &github.SecretScanningAlertListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+
+	var alerts []*SecurityAlert
+	for page := 0; page < securityMaxPages; page++ {
+		items, resp, err := client.GitHub.SecretScanning.ListAlertsForRepo(client.Ctx, owner, repo, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, item := range items {
+			title := item.GetSecretTypeDisplayName()
+			if title == "" {
+				title = item.GetSecretType()
+			}
+			alerts = append(alerts, &SecurityAlert{
+				Feature:   SecuritySecretScanning,
+				Number:    item.GetNumber(),
+				Severity:  SeverityCritical,
+				Title:     title,
+				Location:  item.GetSecretType(),
+				CreatedAt: item.GetCreatedAt().Time,
+				URL:       item.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return alerts, false, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return alerts, true, nil
+}
+
+// normalizeSeverity converte as severidades dos três recursos para a escala
+// critical/high/medium/low (code scanning usa error/warning/note em regras
+// que não são de segurança; Dependabot usa "moderate")
+func normalizeSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return SeverityCritical
+	case "high", "error":
+		return SeverityHigh
+	case "medium", "moderate", "warning":
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// securityDisabledHints são trechos das mensagens de 403 que indicam recurso
+// desativado (ex: "Dependabot alerts are disabled for this repository.",
+// "Advanced Security must be enabled for this repository to use code scanning.")
+var securityDisabledHints = []string{"disabled", "not enabled", "must be enabled"}
+
+// securityStatus traduz o erro da listagem na situação do recurso. O GitHub
+// responde 404 quando o recurso está desativado (ou sem análise) e 403 quando
+// falta permissão, mas alguns recursos desativados também respondem 403.
+func securityStatus(err error) string {
+	if err == nil {
+		return SecurityEnabled
+	}
+	if isNotFound(err) {
+		return SecurityDisabled
+	}
+	var errorResponse *github.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Response != nil &&
+		errorResponse.Response.StatusCode == http.StatusForbidden {
+		message := strings.ToLower(errorResponse.Message)
+		for _, hint := range securityDisabledHints {
+			if strings.Contains(message, hint) {
+				return SecurityDisabled
+			}
+		}
+		return SecurityForbidden
+	}
+	return SecurityError
+}
+
+// securityMessage retorna a mensagem da API, sem o método e a URL da requisição
+func securityMessage(err error) string {
+	var errorResponse *github.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Message != "" {
+		return errorResponse.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,262 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// statsDeadline limita a espera total pelos endpoints de estatísticas, que
+// são consultados em paralelo; enquanto o GitHub responder 202 Accepted a
+// consulta é repetida, com espera inicial de statsRetryDelay dobrando a cada
+// tentativa
+const (
+	statsDeadline   = 10 * time.Second
+	statsRetryDelay = time.Second
+)
+
+// Endpoints de estatísticas, como aparecem em RepositoryData.StatsPending
+const (
+	StatsContributors   = "contributors"
+	StatsCodeFrequency  = "code_frequency"
+	StatsCommitActivity = "commit_activity"
+	StatsParticipation  = "participation"
+	StatsPunchCard      = "punch_card"
+)
+
+// ErrStatsPending indica que o GitHub ainda não terminou de calcular as
+// estatísticas do repositório (respostas 202 Accepted)
+var ErrStatsPending = errors.New("estatísticas ainda sendo calculadas pelo GitHub")
+
+// ContributorActivity guarda os commits semanais de um colaborador, conforme
+// o endpoint de estatísticas. Apenas semanas com atividade são mantidas.
+type ContributorActivity struct {
+	Login string                `json:"login"`
+	Total int                   `json:"total"`
+	Weeks []*WeeklyContribution `json:"weeks"`
+}
+
+// WeeklyContribution representa os commits de um colaborador em uma semana
+type WeeklyContribution struct {
+	Week    time.Time `json:"week"`
+	Commits int       `json:"commits"`
+}
+
+// fetchStats executa uma chamada dos endpoints de estatísticas repetindo-a
+// enquanto a API responder 202 Accepted; ao fim do prazo do contexto retorna
+// ErrStatsPending
+func fetchStats[T any](ctx context.Context, fetch func(ctx context.Context) (T, *github.Response, error)) (T, error) {
+	var zero T
+	delay := statsRetryDelay
+
+	for {
+		result, _, err := fetch(ctx)
+
+		var accepted *github.AcceptedError
+		if !errors.As(err, &accepted) {
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return zero, ErrStatsPending
+			}
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return zero, ErrStatsPending
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// extractStats consulta em paralelo os endpoints de estatísticas (atividade
+// semanal dos colaboradores, code frequency, commit activity, participation e
+// punch card), com um prazo único para todos. Os que o GitHub ainda estiver
+// calculando ao fim do prazo ficam em data.StatsPending, sem atrasar a
+// extração; os que falharem são reportados no erro agregado e os demais são
+// mantidos.
+func extractStats(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	ctx, cancel := context.WithTimeout(client.Ctx, statsDeadline)
+	defer cancel()
+
+	var (
+		contributors  []*github.ContributorStats
+		frequency     []*github.WeeklyStats
+		activity      []*github.WeeklyCommitActivity
+		participation *github.RepositoryParticipation
+		punchCard     []*github.PunchCard
+	)
+	repos := client.GitHub.Repositories
+	endpoints := []struct {
+		name  string
+		fetch func(ctx context.Context) error
+	}{
+		{StatsContributors, func(ctx context.Context) (err error) {
+			contributors, err = fetchStats(ctx, func(ctx context.Context) ([]*github.ContributorStats, *github.Response, error) {
+				return repos.ListContributorsStats(ctx, owner, repo)
+			})
+			return err
+		}},
+		// Code frequency é indisponível em repositórios com 10.000+ commits
+		{StatsCodeFrequency, func(ctx context.Context) (err error) {
+			frequency, err = fetchStats(ctx, func(ctx context.Context) ([]*github.WeeklyStats, *github.Response, error) {
+				return repos.ListCodeFrequency(ctx, owner, repo)
+			})
+			return err
+		}},
+		{StatsCommitActivity, func(ctx context.Context) (err error) {
+			activity, err = fetchStats(ctx, func(ctx context.Context) ([]*github.WeeklyCommitActivity, *github.Response, error) {
+				return repos.ListCommitActivity(ctx, owner, repo)
+			})
+			return err
+		}},
+		{StatsParticipation, func(ctx context.Context) (err error) {
+			participation, err = fetchStats(ctx, func(ctx context.Context) (*github.RepositoryParticipation, *github.Response, error) {
+				return repos.ListParticipation(ctx, owner, repo)
+			})
+			return err
+		}},
+		{StatsPunchCard, func(ctx context.Context) (err error) {
+			punchCard, err = fetchStats(ctx, func(ctx context.Context) ([]*github.PunchCard, *github.Response, error) {
+				return repos.ListPunchCard(ctx, owner, repo)
+			})
+			return err
+		}},
+	}
+
+	results := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, fetch func(ctx context.Context) error) {
+			defer wg.Done()
+			results[i] = fetch(ctx)
+		}(i, endpoint.fetch)
+	}
+	wg.Wait()
+
+	var errs []error
+	available := make(map[string]bool)
+	for i, err := range results {
+		name := endpoints[i].name
+		switch {
+		case err == nil:
+			available[name] = true
+		case errors.Is(err, ErrStatsPending):
+			data.StatsPending = append(data.StatsPending, name)
+		default:
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+		}
+	}
+	if len(data.StatsPending) > 0 {
+		log.Printf("⏳ Estatísticas ainda sendo calculadas pelo GitHub: %s", strings.Join(data.StatsPending, ", "))
+	}
+
+	if available[StatsContributors] {
+		data.ContributorActivity = newContributorActivity(contributors)
+	}
+	if available[StatsCodeFrequency] || available[StatsCommitActivity] ||
+		available[StatsParticipation] || available[StatsPunchCard] {
+		data.Stats = newRepositoryStats(frequency, activity, participation, punchCard)
+	}
+	return errors.Join(errs...)
+}
+
+// newContributorActivity converte a atividade semanal dos principais
+// colaboradores (até 100, limite do endpoint de estatísticas)
+func newContributorActivity(stats []*github.ContributorStats) []*ContributorActivity {
+	activities := make([]*ContributorActivity, 0, len(stats))
+	for _, stat := range stats {
+		activity := &ContributorActivity{
+			Login: stat.GetAuthor().GetLogin(),
+			Total: stat.GetTotal(),
+		}
+		for _, week := range stat.Weeks {
+			if week.GetCommits() == 0 {
+				continue
+			}
+			activity.Weeks = append(activity.Weeks, &WeeklyContribution{
+				Week:    week.GetWeek().Time,
+				Commits: week.GetCommits(),
+			})
+		}
+		activities = append(activities, activity)
+	}
+	return activities
+}
+
+// RepositoryStats agrupa os endpoints de estatísticas do repositório
+type RepositoryStats struct {
+	CodeFrequency  []*WeeklyCodeFrequency  `json:"code_frequency"`
+	CommitActivity []*WeeklyCommitActivity `json:"commit_activity"`
+	Participation  *Participation          `json:"participation"`
+	PunchCard      []*PunchCardEntry       `json:"punch_card"`
+}
+
+// WeeklyCodeFrequency representa linhas adicionadas e removidas em uma semana
+type WeeklyCodeFrequency struct {
+	Week      time.Time `json:"week"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+}
+
+// WeeklyCommitActivity representa os commits de uma semana, por dia (0 = domingo)
+type WeeklyCommitActivity struct {
+	Week  time.Time `json:"week"`
+	Total int       `json:"total"`
+	Days  []int     `json:"days"`
+}
+
+// Participation guarda os commits semanais das últimas 52 semanas, de todos
+// e apenas do dono do repositório (da mais antiga para a mais recente)
+type Participation struct {
+	All   []int `json:"all"`
+	Owner []int `json:"owner"`
+}
+
+// PunchCardEntry representa os commits em uma hora de um dia da semana
+type PunchCardEntry struct {
+	Day     int `json:"day"`
+	Hour    int `json:"hour"`
+	Commits int `json:"commits"`
+}
+
+// newRepositoryStats converte as respostas dos endpoints de code frequency,
+// commit activity, participation e punch card
+func newRepositoryStats(frequency []*github.WeeklyStats, activity []*github.WeeklyCommitActivity,
+	participation *github.RepositoryParticipation, punchCard []*github.PunchCard) *RepositoryStats {
+	stats := &RepositoryStats{}
+	for _, week := range frequency {
+		stats.CodeFrequency = append(stats.CodeFrequency, &WeeklyCodeFrequency{
+			Week:      week.GetWeek().Time,
+			Additions: week.GetAdditions(),
+			Deletions: -week.GetDeletions(), // A API retorna remoções como valores negativos
+		})
+	}
+	for _, week := range activity {
+		stats.CommitActivity = append(stats.CommitActivity, &WeeklyCommitActivity{
+			Week:  week.GetWeek().Time,
+			Total: week.GetTotal(),
+			Days:  week.Days,
+		})
+	}
+	if participation != nil {
+		stats.Participation = &Participation{All: participation.All, Owner: participation.Owner}
+	}
+	for _, entry := range punchCard {
+		stats.PunchCard = append(stats.PunchCard, &PunchCardEntry{
+			Day:     entry.GetDay(),
+			Hour:    entry.GetHour(),
+			Commits: entry.GetCommits(),
+		})
+	}
+	return stats
+}
@@ -0,0 +1,190 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// treeMaxRequests limita as requisições usadas para remontar uma árvore que a
+// API devolveu truncada, percorrendo as subárvores uma a uma
+const treeMaxRequests = 40
+
+// errTreeLimit indica que o limite de requisições da remontagem foi atingido
+var errTreeLimit = errors.New("limite de requisições atingido")
+
+// RepositoryTree guarda os arquivos do branch padrão (Git Trees API, recursiva).
+// Diretórios e submódulos não são listados. Truncated indica que a árvore
+// final está incompleta, mesmo após a remontagem por subárvores.
+type RepositoryTree struct {
+	SHA       string      `json:"sha"`
+	Truncated bool        `json:"truncated"`
+	Files     []*TreeFile `json:"files"`
+
+	// Walked indica que a resposta recursiva veio truncada e a árvore foi
+	// remontada por subárvores; MissingDirs lista o que ficou de fora
+	Walked      bool     `json:"walked,omitempty"`
+	Requests    int      `json:"requests"`
+	MissingDirs []string `json:"missing_dirs,omitempty"`
+}
+
+// TreeFile representa um arquivo da árvore do repositório
+type TreeFile struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+// treeWalkItem é uma subárvore pendente na remontagem da árvore; partial
+// indica que ela veio pela metade na resposta truncada e é listada por nível
+type treeWalkItem struct {
+	path    string
+	sha     string
+	partial bool
+}
+
+// extractTree obtém a árvore completa do branch padrão em uma única requisição.
+// Acima do limite da API (100.000 entradas ou 7 MB) a resposta vem truncada;
+// nesse caso as subárvores que vieram completas são aproveitadas e as demais
+// são buscadas uma a uma, até treeMaxRequests. As requisições entram no custo
+// medido da extração (ver scheduler.Budget).
+func extractTree(client *ghclient.Client, owner, repo string, data *RepositoryData) error {
+	ref := "HEAD"
+	if data.BasicInfo != nil && data.BasicInfo.DefaultBranch != "" {
+		ref = data.BasicInfo.DefaultBranch
+	}
+
+	tree, _, err := client.GitHub.Git.GetTree(client.Ctx, owner, repo, ref, true)
+	if err != nil {
+		return err
+	}
+
+	result := &RepositoryTree{SHA: tree.GetSHA(), Requests: 1}
+	data.Tree = result
+	if !tree.GetTruncated() {
+		result.Files = treeFiles("", tree.Entries)
+		return nil
+	}
+
+	result.Walked = true
+	completeDirs, partialDirs := truncatedTreeDirs(tree.Entries)
+	var reused []*github.TreeEntry
+	for _, entry := range tree.Entries {
+		if completeDirs[path.Dir(entry.GetPath())] {
+			reused = append(reused, entry)
+		}
+	}
+	result.Files = treeFiles("", reused)
+
+	pending := []treeWalkItem{{sha: result.SHA, partial: true}}
+	var firstErr error
+	for len(pending) > 0 {
+		item := pending[0]
+		pending = pending[1:]
+
+		entries, complete, err := walkSubtree(client, owner, repo, item, result)
+		if err != nil {
+			if firstErr == nil && !errors.Is(err, errTreeLimit) {
+				firstErr = fmt.Errorf("subárvore %q: %v", item.path, err)
+			}
+			result.MissingDirs = append(result.MissingDirs, item.path+"/")
+			continue
+		}
+
+		result.Files = append(result.Files, treeFiles(item.path, entries)...)
+		if complete {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.GetType() != "tree" {
+				continue
+			}
+			dir := path.Join(item.path, entry.GetPath())
+			if completeDirs[dir] {
+				continue
+			}
+			pending = append(pending, treeWalkItem{path: dir, sha: entry.GetSHA(), partial: partialDirs[dir]})
+		}
+	}
+
+	result.Truncated = len(result.MissingDirs) > 0
+	if result.Truncated && firstErr == nil {
+		return fmt.Errorf("árvore incompleta: %d diretórios não listados (limite de %d requisições)",
+			len(result.MissingDirs), treeMaxRequests)
+	}
+	return firstErr
+}
+
+// truncatedTreeDirs separa os diretórios da resposta recursiva truncada. A API
+// lista as entradas em pré-ordem, então apenas a raiz e os diretórios no
+// caminho da última entrada podem ter vindo pela metade (partial); os demais
+// diretórios listados vieram completos, com todos os seus arquivos.
+func truncatedTreeDirs(entries []*github.TreeEntry) (complete, partial map[string]bool) {
+	complete, partial = make(map[string]bool), map[string]bool{"": true}
+	if len(entries) == 0 {
+		return complete, partial
+	}
+
+	last := entries[len(entries)-1]
+	if last.GetType() == "tree" {
+		partial[last.GetPath()] = true
+	}
+	for dir := path.Dir(last.GetPath()); dir != "."; dir = path.Dir(dir) {
+		partial[dir] = true
+	}
+
+	for _, entry := range entries {
+		if entry.GetType() == "tree" && !partial[entry.GetPath()] {
+			complete[entry.GetPath()] = true
+		}
+	}
+	return complete, partial
+}
+
+// walkSubtree lista uma subárvore inteira (complete = true) ou, quando ela
+// também vem truncada, apenas o seu primeiro nível. Subárvores que vieram pela
+// metade na resposta truncada, como a raiz, são sempre listadas por nível.
+func walkSubtree(client *ghclient.Client, owner, repo string, item treeWalkItem, result *RepositoryTree) ([]*github.TreeEntry, bool, error) {
+	if !item.partial {
+		if result.Requests >= treeMaxRequests {
+			return nil, false, errTreeLimit
+		}
+		result.Requests++
+		subtree, _, err := client.GitHub.Git.GetTree(client.Ctx, owner, repo, item.sha, true)
+		if err != nil {
+			return nil, false, err
+		}
+		if !subtree.GetTruncated() {
+			return subtree.Entries, true, nil
+		}
+	}
+
+	if result.Requests >= treeMaxRequests {
+		return nil, false, errTreeLimit
+	}
+	result.Requests++
+	level, _, err := client.GitHub.Git.GetTree(client.Ctx, owner, repo, item.sha, false)
+	if err != nil {
+		return nil, false, err
+	}
+	return level.Entries, false, nil
+}
+
+// treeFiles converte as entradas de arquivo da API, prefixando o caminho da
+// subárvore de onde vieram
+func treeFiles(prefix string, entries []*github.TreeEntry) []*TreeFile {
+	files := make([]*TreeFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.GetType() != "blob" {
+			continue
+		}
+		files = append(files, &TreeFile{
+			Path: path.Join(prefix, entry.GetPath()),
+			Size: entry.GetSize(),
+		})
+	}
+	return files
+}
@@ -0,0 +1,186 @@
+package extractor
+
+import (
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// recentSampleSize limita as listas recentes atualizadas por webhook ao mesmo
+// tamanho usado na extração
+const recentSampleSize = 10
+
+// ApplyWebhookEvent aplica um evento de webhook (push, issues, pull_request ou
+// release) sobre os dados extraídos e retorna uma cópia atualizada. Os dados
+// originais não são alterados, pois podem estar em uso por outras requisições.
+// Retorna false para eventos não suportados.
+func ApplyWebhookEvent(data *RepositoryData, event interface{}) (*RepositoryData, bool) {
+	updated := *data
+
+	var eventType, actor string
+	switch e := event.(type) {
+	case *github.PushEvent:
+		eventType, actor = "PushEvent", e.GetSender().GetLogin()
+		applyPushEvent(&updated, e)
+
+	case *github.IssuesEvent:
+		if e.GetIssue().IsPullRequest() {
+			return data, false
+		}
+		eventType, actor = "IssuesEvent", e.GetSender().GetLogin()
+		applyRepositoryStatistics(&updated, e.GetRepo())
+
+		issue := newIssueData(e.GetIssue())
+		sameIssue := func(other *IssueData) bool { return other.Number == issue.Number }
+		updated.IssueCounts = applyIssueCounts(updated.IssueCounts, e.GetAction(), issue.State,
+			wasStaleIssue(updated.RecentIssues, sameIssue))
+		if action := e.GetAction(); action == "deleted" || action == "transferred" {
+			updated.RecentIssues = removeRecent(updated.RecentIssues, sameIssue)
+		} else {
+			updated.RecentIssues = upsertRecent(updated.RecentIssues, issue, sameIssue)
+		}
+
+	case *github.PullRequestEvent:
+		eventType, actor = "PullRequestEvent", e.GetSender().GetLogin()
+		applyRepositoryStatistics(&updated, e.GetRepo())
+
+		pr := newPullRequestData(e.GetPullRequest())
+		updated.RecentPRs = upsertRecent(updated.RecentPRs, pr, func(other *PullRequestData) bool {
+			return other.Number == pr.Number
+		})
+
+	case *github.ReleaseEvent:
+		eventType, actor = "ReleaseEvent", e.GetSender().GetLogin()
+		applyRepositoryStatistics(&updated, e.GetRepo())
+
+		release := newReleaseData(e.GetRelease())
+		sameRelease := func(other *ReleaseData) bool { return other.TagName == release.TagName }
+		if e.GetAction() == "deleted" {
+			updated.Releases = removeRecent(updated.Releases, sameRelease)
+		} else {
+			// Os releases guardam o histórico usado na análise de cadência, maior
+			// que as demais listas recentes
+			updated.Releases = append([]*ReleaseData{release}, removeRecent(updated.Releases, sameRelease)...)
+			if len(updated.Releases) > ReleaseHistorySize {
+				updated.Releases = updated.Releases[:ReleaseHistorySize]
+			}
+		}
+
+	default:
+		return data, false
+	}
+
+	updated.RecentEvents = limitRecent(append([]*EventData{{
+		Type:      eventType,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+		Public:    updated.Settings == nil || !updated.Settings.Private,
+	}}, updated.RecentEvents...))
+
+	return &updated, true
+}
+
+// applyPushEvent atualiza data do último push, estatísticas e, para pushes no
+// branch padrão, a lista de commits recentes
+func applyPushEvent(data *RepositoryData, event *github.PushEvent) {
+	repo := event.GetRepo()
+
+	if data.BasicInfo != nil && repo != nil {
+		info := *data.BasicInfo
+		if pushedAt := repo.GetPushedAt().Time; !pushedAt.IsZero() {
+			info.PushedAt = pushedAt
+		}
+		if repo.Size != nil {
+			info.Size = repo.GetSize()
+		}
+		data.BasicInfo = &info
+	}
+
+	if data.Statistics != nil && repo != nil {
+		stats := *data.Statistics
+		stats.Stars = repo.GetStargazersCount()
+		stats.Forks = repo.GetForksCount()
+		stats.Watchers = repo.GetWatchersCount()
+		stats.Issues = repo.GetOpenIssuesCount()
+		data.Statistics = &stats
+	}
+
+	defaultBranch := repo.GetDefaultBranch()
+	if data.BasicInfo != nil && data.BasicInfo.DefaultBranch != "" {
+		defaultBranch = data.BasicInfo.DefaultBranch
+	}
+	if event.GetRef() != "refs/heads/"+defaultBranch {
+		return
+	}
+
+	// Os commits do payload vêm do mais antigo para o mais recente. O histórico
+	// da análise das mensagens só é atualizado quando veio da extração (dados
+	// antigos não o têm); o payload não traz pais nem verificação da assinatura.
+	for _, commit := range event.Commits {
+		entry := &CommitData{
+			SHA:       commit.GetID(),
+			Message:   commit.GetMessage(),
+			Author:    commit.GetAuthor().GetName(),
+			CreatedAt: commit.GetTimestamp().Time,
+			URL:       commit.GetURL(),
+		}
+		same := func(other *CommitData) bool { return other.SHA == entry.SHA }
+		data.RecentCommits = upsertRecent(data.RecentCommits, entry, same)
+		if len(data.CommitHistory) > 0 {
+			data.CommitHistory = append([]*CommitData{entry}, removeRecent(data.CommitHistory, same)...)
+			data.CommitHistory = data.CommitHistory[:min(len(data.CommitHistory), CommitHistorySize)]
+		}
+	}
+}
+
+// applyRepositoryStatistics atualiza as estatísticas com o repositório enviado
+// no payload do webhook
+func applyRepositoryStatistics(data *RepositoryData, repo *github.Repository) {
+	if repo == nil || data.Statistics == nil {
+		return
+	}
+
+	stats := *data.Statistics
+	stats.Stars = repo.GetStargazersCount()
+	stats.Forks = repo.GetForksCount()
+	stats.Watchers = repo.GetWatchersCount()
+	stats.Issues = repo.GetOpenIssuesCount()
+	data.Statistics = &stats
+}
+
+// wasStaleIssue verifica se a versão em cache da issue estava aberta e
+// obsoleta; issues fora da amostra recente retornam false
+func wasStaleIssue(issues []*IssueData, same func(*IssueData) bool) bool {
+	staleThreshold := time.Now().AddDate(0, 0, -StaleIssueDays)
+	for _, issue := range issues {
+		if same(issue) {
+			return issue.State == "open" && issue.UpdatedAt.Before(staleThreshold)
+		}
+	}
+	return false
+}
+
+// upsertRecent coloca o item no início da lista, removendo a versão anterior,
+// e limita o tamanho da lista
+func upsertRecent[T any](items []T, item T, same func(T) bool) []T {
+	return limitRecent(append([]T{item}, removeRecent(items, same)...))
+}
+
+// limitRecent mantém apenas os itens mais recentes da lista
+func limitRecent[T any](items []T) []T {
+	if len(items) > recentSampleSize {
+		return items[:recentSampleSize]
+	}
+	return items
+}
+
+// removeRecent retorna uma nova lista sem os itens equivalentes
+func removeRecent[T any](items []T, same func(T) bool) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if !same(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
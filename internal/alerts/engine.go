@@ -0,0 +1,75 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// Engine avalia as regras após cada extração e envia os alertas disparados
+// para os notificadores configurados
+type Engine struct {
+	rules     []Rule
+	analyzer  utils.Analyzer
+	notifiers []Notifier
+}
+
+// NewEngine cria o avaliador de alertas; analyzer analisa o snapshot
+// anterior com as mesmas opções da análise atual
+func NewEngine(rules []Rule, analyzer utils.Analyzer, notifiers ...Notifier) *Engine {
+	return &Engine{rules: rules, analyzer: analyzer, notifiers: notifiers}
+}
+
+// ChangeRules retorna os nomes das regras de variação (delta ou percent), que
+// só são avaliadas quando há um snapshot anterior
+func (e *Engine) ChangeRules() []string {
+	if e == nil {
+		return nil
+	}
+
+	var names []string
+	for _, rule := range e.rules {
+		if rule.Change != ChangeNone {
+			names = append(names, rule.Name)
+		}
+	}
+	return names
+}
+
+// Process avalia as regras para os dados extraídos, registra os alertas no
+// log e os envia aos notificadores. previous é o snapshot anterior usado
+// pelas regras de variação (nil: apenas regras absolutas). Retorna os alertas
+// disparados e o erro agregado dos envios que falharam.
+func (e *Engine) Process(data *extractor.RepositoryData, analysis *utils.Analysis, previous *extractor.RepositoryData) ([]*Alert, error) {
+	if e == nil || len(e.rules) == 0 {
+		return nil, nil
+	}
+
+	var previousAnalysis *utils.Analysis
+	if previous != nil {
+		previousAnalysis = e.analyzer.Analyze(previous)
+	}
+
+	alerts := Evaluate(e.rules, data, analysis, previous, previousAnalysis)
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	for _, alert := range alerts {
+		log.Printf("🚨 [%s] %s: %s", alert.Severity, alert.Repository, alert.Message)
+	}
+
+	var errs []error
+	for _, notifier := range e.notifiers {
+		if err := notifier.Notify(alerts); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", notifier.Name(), err))
+			continue
+		}
+		log.Printf("📨 %d alerta(s) enviados via %s", len(alerts), notifier.Name())
+	}
+
+	return alerts, errors.Join(errs...)
+}
@@ -0,0 +1,138 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// notifyTimeout limita o tempo de cada envio HTTP
+const notifyTimeout = 10 * time.Second
+
+// Notifier envia os alertas disparados para um destino externo
+type Notifier interface {
+	Name() string
+	Notify(alerts []*Alert) error
+}
+
+// WebhookNotifier envia os alertas como JSON genérico via POST
+type WebhookNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+// webhookPayload é o corpo enviado pelo WebhookNotifier
+type webhookPayload struct {
+	Source string   `json:"source"`
+	Alerts []*Alert `json:"alerts"`
+}
+
+// NewWebhookNotifier cria um notificador de webhook genérico
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, client: &http.Client{Timeout: notifyTimeout}}
+}
+
+func (n *WebhookNotifier) Name() string { return "webhook" }
+
+func (n *WebhookNotifier) Notify(alerts []*Alert) error {
+	return postJSON(n.client, n.URL, &webhookPayload{Source: "github-repository-analyzer", Alerts: alerts})
+}
+
+// SlackNotifier envia os alertas para um incoming webhook compatível com Slack
+type SlackNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+// NewSlackNotifier cria um notificador para incoming webhooks do Slack
+// (ou serviços compatíveis, como Mattermost e Rocket.Chat)
+func NewSlackNotifier(url string) *SlackNotifier {
+	return &SlackNotifier{URL: url, client: &http.Client{Timeout: notifyTimeout}}
+}
+
+func (n *SlackNotifier) Name() string { return "slack" }
+
+func (n *SlackNotifier) Notify(alerts []*Alert) error {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🚨 *%d alerta(s) de repositórios GitHub*\n", len(alerts)))
+	for _, alert := range alerts {
+		repository := alert.Repository
+		if alert.URL != "" {
+			repository = fmt.Sprintf("<%s|%s>", alert.URL, alert.Repository)
+		}
+		text.WriteString(fmt.Sprintf("• [%s] %s — %s\n", alert.Severity, repository, alert.Message))
+	}
+
+	return postJSON(n.client, n.URL, map[string]string{"text": text.String()})
+}
+
+// EmailNotifier envia os alertas por email via SMTP
+type EmailNotifier struct {
+	Addr     string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+func (n *EmailNotifier) Name() string { return "email" }
+
+func (n *EmailNotifier) Notify(alerts []*Alert) error {
+	subject := fmt.Sprintf("[GitHub Analyzer] %d alerta(s)", len(alerts))
+
+	var body strings.Builder
+	for _, alert := range alerts {
+		body.WriteString(fmt.Sprintf("[%s] %s\n    %s\n", alert.Severity, alert.Repository, alert.Message))
+		if alert.URL != "" {
+			body.WriteString("    " + alert.URL + "\n")
+		}
+		body.WriteString("\n")
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("From: " + n.From + "\r\n")
+	msg.WriteString("To: " + strings.Join(n.To, ", ") + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+
+	// Sem usuário, o envio é feito sem autenticação (ex: relay local)
+	var auth smtp.Auth
+	if n.Username != "" {
+		host, _, err := net.SplitHostPort(n.Addr)
+		if err != nil {
+			return fmt.Errorf("endereço SMTP inválido %q: %v", n.Addr, err)
+		}
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+
+	return smtp.SendMail(n.Addr, auth, n.From, n.To, msg.Bytes())
+}
+
+// postJSON envia o corpo serializado e exige resposta 2xx
+func postJSON(client *http.Client, url string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("resposta inesperada de %s: %s", url, resp.Status)
+	}
+	return nil
+}
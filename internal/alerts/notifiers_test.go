@@ -0,0 +1,290 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// testAlerts são os alertas enviados nos testes dos notificadores
+var testAlerts = []*Alert{
+	{Rule: "Stars caíram", Repository: "octo/app", URL: "https://github.com/octo/app", Metric: "stars", Value: -3, Severity: "info", Message: "stars caíram 3"},
+	{Rule: "Saúde baixa", Repository: "octo/lib", Metric: "health_score", Value: 40, Severity: "critical", Message: "score 40 < 50"},
+}
+
+// request é uma requisição recebida pelo servidor local de teste
+type request struct {
+	method      string
+	contentType string
+	body        []byte
+}
+
+// newTestServer sobe um servidor local que registra as requisições e responde
+// com o status informado
+func newTestServer(t *testing.T, status int) (*httptest.Server, *[]request) {
+	var received []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("erro ao ler corpo: %v", err)
+		}
+		received = append(received, request{method: r.Method, contentType: r.Header.Get("Content-Type"), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"resposta 200", http.StatusOK, false},
+		{"resposta 204", http.StatusNoContent, false},
+		{"resposta 500", http.StatusInternalServerError, true},
+		{"resposta 404", http.StatusNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newTestServer(t, tt.status)
+
+			err := NewWebhookNotifier(server.URL).Notify(testAlerts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erro = %v, esperado erro: %v", err, tt.wantErr)
+			}
+			if len(*received) != 1 {
+				t.Fatalf("%d requisições recebidas, esperado 1", len(*received))
+			}
+
+			req := (*received)[0]
+			if req.method != http.MethodPost || req.contentType != "application/json" {
+				t.Errorf("requisição %s %q, esperado POST application/json", req.method, req.contentType)
+			}
+			var payload webhookPayload
+			if err := json.Unmarshal(req.body, &payload); err != nil {
+				t.Fatalf("payload inválido: %v", err)
+			}
+			if payload.Source != "github-repository-analyzer" || len(payload.Alerts) != len(testAlerts) {
+				t.Fatalf("payload = %+v, esperado %d alertas", payload, len(testAlerts))
+			}
+			if got := payload.Alerts[1]; got.Rule != "Saúde baixa" || got.Value != 40 || got.Severity != "critical" {
+				t.Errorf("alerta = %+v, esperado %+v", got, testAlerts[1])
+			}
+		})
+	}
+}
+
+func TestSlackNotifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"resposta 200", http.StatusOK, false},
+		{"resposta 403", http.StatusForbidden, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newTestServer(t, tt.status)
+
+			err := NewSlackNotifier(server.URL).Notify(testAlerts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erro = %v, esperado erro: %v", err, tt.wantErr)
+			}
+			if len(*received) != 1 {
+				t.Fatalf("%d requisições recebidas, esperado 1", len(*received))
+			}
+
+			var payload map[string]string
+			if err := json.Unmarshal((*received)[0].body, &payload); err != nil {
+				t.Fatalf("payload inválido: %v", err)
+			}
+			for _, want := range []string{
+				"*2 alerta(s) de repositórios GitHub*",
+				"• [info] <https://github.com/octo/app|octo/app> — stars caíram 3",
+				"• [critical] octo/lib — score 40 < 50",
+			} {
+				if !strings.Contains(payload["text"], want) {
+					t.Errorf("texto %q não contém %q", payload["text"], want)
+				}
+			}
+		})
+	}
+}
+
+// smtpSession é uma entrega recebida pelo servidor SMTP local de teste
+type smtpSession struct {
+	from    string
+	to      []string
+	message []byte
+}
+
+// newTestSMTPServer sobe um servidor SMTP mínimo (EHLO, MAIL, RCPT, DATA e
+// QUIT, sem TLS nem autenticação) que registra a entrega e responde a RCPT
+// com o código informado
+func newTestSMTPServer(t *testing.T, rcptCode int) (string, <-chan smtpSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("erro ao abrir porta SMTP: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var session smtpSession
+		text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				text.PrintfLine("250 localhost")
+			case "MAIL":
+				session.from = strings.TrimPrefix(line, "MAIL FROM:")
+				text.PrintfLine("250 OK")
+			case "RCPT":
+				session.to = append(session.to, strings.TrimPrefix(line, "RCPT TO:"))
+				text.PrintfLine("%d destinatário", rcptCode)
+			case "DATA":
+				text.PrintfLine("354 envie a mensagem")
+				if session.message, err = text.ReadDotBytes(); err != nil {
+					return
+				}
+				text.PrintfLine("250 OK")
+				sessions <- session
+			case "QUIT":
+				text.PrintfLine("221 tchau")
+				return
+			default:
+				text.PrintfLine("502 comando não suportado")
+			}
+		}
+	}()
+	return listener.Addr().String(), sessions
+}
+
+func TestEmailNotifier(t *testing.T) {
+	addr, sessions := newTestSMTPServer(t, 250)
+	notifier := &EmailNotifier{Addr: addr, From: "alerts@example.com", To: []string{"dev@example.com", "ops@example.com"}}
+
+	if err := notifier.Notify(testAlerts); err != nil {
+		t.Fatalf("erro ao enviar email: %v", err)
+	}
+
+	var session smtpSession
+	select {
+	case session = <-sessions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("servidor SMTP não recebeu a mensagem")
+	}
+
+	if session.from != "<alerts@example.com>" {
+		t.Errorf("MAIL FROM = %q, esperado <alerts@example.com>", session.from)
+	}
+	if want := []string{"<dev@example.com>", "<ops@example.com>"}; !slices.Equal(session.to, want) {
+		t.Errorf("RCPT TO = %q, esperado %q", session.to, want)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(session.message))
+	if err != nil {
+		t.Fatalf("mensagem inválida: %v", err)
+	}
+	if got := msg.Header.Get("To"); got != "dev@example.com, ops@example.com" {
+		t.Errorf("To = %q, esperado os dois destinatários", got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "[GitHub Analyzer] 2 alerta(s)" {
+		t.Errorf("Subject = %q (%v), esperado \"[GitHub Analyzer] 2 alerta(s)\"", subject, err)
+	}
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatalf("erro ao ler corpo: %v", err)
+	}
+	for _, want := range []string{
+		"[info] octo/app\n    stars caíram 3\n    https://github.com/octo/app\n",
+		"[critical] octo/lib\n    score 40 < 50\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("corpo %q não contém %q", body, want)
+		}
+	}
+}
+
+func TestEmailNotifierRejected(t *testing.T) {
+	addr, _ := newTestSMTPServer(t, 550)
+	notifier := &EmailNotifier{Addr: addr, From: "alerts@example.com", To: []string{"dev@example.com"}}
+
+	if err := notifier.Notify(testAlerts); err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("erro = %v, esperado a recusa 550 do destinatário", err)
+	}
+}
+
+func TestNotifierUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	for _, notifier := range []Notifier{NewWebhookNotifier(url), NewSlackNotifier(url)} {
+		if err := notifier.Notify(testAlerts); err == nil {
+			t.Errorf("%s: envio para servidor fechado deveria falhar", notifier.Name())
+		}
+	}
+}
+
+func TestEngineProcessNotifies(t *testing.T) {
+	ok, okReceived := newTestServer(t, http.StatusOK)
+	failing, _ := newTestServer(t, http.StatusBadGateway)
+
+	rules := []Rule{
+		{Name: "Poucas stars", Metric: "stars", Op: "<", Value: 100},
+		{Name: "Stars caíram", Metric: "stars", Op: "<", Value: 0, Change: ChangeDelta},
+	}
+	engine := NewEngine(rules, utils.Analyzer{}, NewWebhookNotifier(ok.URL), NewSlackNotifier(failing.URL))
+	data := &extractor.RepositoryData{
+		BasicInfo:  &extractor.BasicInfo{FullName: "octo/app", URL: "https://github.com/octo/app"},
+		Statistics: &extractor.Statistics{Stars: 10},
+	}
+
+	// Sem snapshot anterior a regra de variação não é avaliada
+	fired, err := engine.Process(data, nil, nil)
+	if len(fired) != 1 || fired[0].Rule != "Poucas stars" || fired[0].Severity != "warning" {
+		t.Fatalf("alertas disparados = %+v, esperado apenas \"Poucas stars\"", fired)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "slack: ") {
+		t.Errorf("erro = %v, esperado a falha do notificador slack", err)
+	}
+	if len(*okReceived) != 1 {
+		t.Errorf("webhook recebeu %d requisições, esperado 1", len(*okReceived))
+	}
+
+	if got := engine.ChangeRules(); len(got) != 1 || got[0] != "Stars caíram" {
+		t.Errorf("ChangeRules = %q, esperado [\"Stars caíram\"]", got)
+	}
+}
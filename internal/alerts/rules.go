@@ -0,0 +1,373 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// Tipos de comparação de uma regra
+const (
+	// ChangeNone compara o valor atual com o limite
+	ChangeNone = ""
+	// ChangeDelta compara a diferença absoluta em relação ao snapshot anterior
+	ChangeDelta = "delta"
+	// ChangePercent compara a variação percentual em relação ao snapshot anterior
+	ChangePercent = "percent"
+)
+
+// Rule define uma condição de alerta sobre uma métrica do repositório
+type Rule struct {
+	Name     string  `json:"name"`
+	Metric   string  `json:"metric"`
+	Op       string  `json:"op"`
+	Value    float64 `json:"value"`
+	Change   string  `json:"change,omitempty"`
+	Severity string  `json:"severity,omitempty"`
+}
+
+// Alert representa uma regra disparada para um repositório
+type Alert struct {
+	Rule        string    `json:"rule"`
+	Repository  string    `json:"repository"`
+	URL         string    `json:"url,omitempty"`
+	Metric      string    `json:"metric"`
+	Value       float64   `json:"value"`
+	Previous    *float64  `json:"previous,omitempty"`
+	Threshold   float64   `json:"threshold"`
+	Severity    string    `json:"severity"`
+	Message     string    `json:"message"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+// noData marca métricas sem dados para calcular (ex: dias desde o último
+// commit de um repositório sem commits); regras sobre elas não são avaliadas
+var noData = math.NaN()
+
+// alertMetric descreve uma métrica disponível para as regras
+type alertMetric struct {
+	label string
+	value func(d *extractor.RepositoryData, a *utils.Analysis) float64
+}
+
+// alertMetrics lista as métricas aceitas no campo "metric" das regras
+var alertMetrics = map[string]alertMetric{
+	"stars": {"Stars",
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Stars) }},
+	"forks": {"Forks",
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Forks) }},
+	"watchers": {"Watchers",
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Watchers) }},
+	"open_issues": {"Issues abertas",
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Issues) }},
+	"health_score": {"Score de saúde",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return a.Health.HealthScore }},
+	"last_commit_days": {"Dias desde o último commit",
+		func(d *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if len(d.RecentCommits) == 0 {
+				return noData
+			}
+			return float64(a.Health.LastCommitDays)
+		}},
+	"last_release_days": {"Dias desde o último release",
+		func(d *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if len(d.Releases) == 0 {
+				return noData
+			}
+			return float64(a.Health.LastReleaseDays)
+		}},
+	"open_issues_ratio": {"Ratio de issues abertas",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return a.Health.OpenIssuesRatio }},
+	"stale_issues": {"Issues obsoletas",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.Health.StaleIssues) }},
+	"commits_last_week": {"Commits na última semana",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Activity.CommitsLastWeek)
+		}},
+	"commits_last_month": {"Commits no último mês",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Activity.CommitsLastMonth)
+		}},
+	"contributors": {"Colaboradores",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Contributors.TotalContributors)
+		}},
+	"truck_factor": {"Truck factor",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.BusFactor.TruckFactor) }},
+	"contributors_gini": {"Gini dos commits",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return a.BusFactor.Gini }},
+	"core_committers": {"Núcleo (80% dos commits)",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.BusFactor.CoreCommitters)
+		}},
+	"community_missing": {"Arquivos de comunidade ausentes",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Community == nil {
+				return noData
+			}
+			return float64(len(a.Community.Missing))
+		}},
+	"codeowners_coverage": {"Cobertura do CODEOWNERS (%)",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.CodeOwners == nil || !a.CodeOwners.TreeAvailable {
+				return noData
+			}
+			return a.CodeOwners.Coverage
+		}},
+	"codeowners_invalid_owners": {"Donos inválidos no CODEOWNERS",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.CodeOwners == nil {
+				return noData
+			}
+			return float64(len(a.CodeOwners.InvalidOwners))
+		}},
+	"tested_packages_ratio": {"Fração de pacotes com testes",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Tree == nil || a.Tree.Packages == 0 {
+				return noData
+			}
+			return a.Tree.TestedShare()
+		}},
+	"dependencies": {"Dependências",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Dependencies == nil {
+				return 0
+			}
+			return float64(a.Dependencies.Total)
+		}},
+	"direct_dependencies": {"Dependências diretas",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Dependencies == nil {
+				return 0
+			}
+			return float64(a.Dependencies.Direct)
+		}},
+	"security_alerts": {"Alertas de segurança abertos",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Security == nil {
+				return 0
+			}
+			return float64(a.Security.Open)
+		}},
+	"security_critical_alerts": {"Alertas de segurança críticos",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Security == nil {
+				return 0
+			}
+			return float64(a.Security.Count(extractor.SeverityCritical))
+		}},
+	"security_score": {"Score de segurança",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Security == nil {
+				return 100
+			}
+			return a.Security.Score
+		}},
+	"days_since_stable_release": {"Dias desde o último release estável",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Releases == nil {
+				return 0
+			}
+			return float64(a.Releases.DaysSinceStable)
+		}},
+	"release_interval_median_days": {"Mediana de dias entre releases",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Releases == nil {
+				return 0
+			}
+			return a.Releases.MedianIntervalDays
+		}},
+	"release_downloads": {"Downloads dos releases",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Downloads == nil {
+				return 0
+			}
+			return float64(a.Downloads.Total)
+		}},
+	"conventional_commits_ratio": {"Fração de Conventional Commits",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Commits == nil || a.Commits.Analyzed == 0 {
+				return noData
+			}
+			return a.Commits.ConventionalRatio
+		}},
+	"verified_commits_ratio": {"Fração de commits verificados",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Commits == nil || a.Commits.VerificationKnown == 0 {
+				return noData
+			}
+			return a.Commits.VerifiedRatio
+		}},
+	"unlinked_commits_ratio": {"Fração de commits sem issue vinculada",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Commits == nil || a.Commits.Analyzed == 0 {
+				return noData
+			}
+			return a.Commits.UnlinkedRatio
+		}},
+	"commit_trend_percent": {"Variação de commits (4 semanas)",
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Stats == nil {
+				return 0
+			}
+			return a.Stats.TrendPercent
+		}},
+}
+
+// operators lista as comparações aceitas no campo "op"
+var operators = map[string]func(value, threshold float64) bool{
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// DefaultRules são usadas quando nenhum arquivo de regras é configurado
+var DefaultRules = []Rule{
+	{Name: "Saúde abaixo de 60", Metric: "health_score", Op: "<", Value: 60, Severity: "warning"},
+	{Name: "Sem commits há 30 dias", Metric: "last_commit_days", Op: ">", Value: 30, Severity: "warning"},
+	{Name: "Stars caíram", Metric: "stars", Op: "<", Value: 0, Change: ChangeDelta, Severity: "info"},
+	{Name: "Muitas issues abertas", Metric: "open_issues_ratio", Op: ">", Value: 0.8, Severity: "warning"},
+}
+
+// LoadRules lê as regras de um arquivo JSON (lista de objetos Rule)
+func LoadRules(path string) ([]Rule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler regras de alerta: %v", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("regras de alerta inválidas em %s: %v", path, err)
+	}
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("%s: regra %d: %v", path, i+1, err)
+		}
+		if rule.Name == "" {
+			rules[i].Name = fmt.Sprintf("%s %s %g", rule.Metric, rule.Op, rule.Value)
+		}
+	}
+	return rules, nil
+}
+
+// validate verifica métrica, operador e tipo de comparação da regra
+func (r Rule) validate() error {
+	if _, ok := alertMetrics[r.Metric]; !ok {
+		return fmt.Errorf("métrica %q desconhecida (disponíveis: %s)", r.Metric, strings.Join(MetricNames(), ", "))
+	}
+	if _, ok := operators[r.Op]; !ok {
+		return fmt.Errorf("operador %q inválido (use <, <=, >, >=, == ou !=)", r.Op)
+	}
+	switch r.Change {
+	case ChangeNone, ChangeDelta, ChangePercent:
+	default:
+		return fmt.Errorf("change %q inválido (use delta ou percent)", r.Change)
+	}
+	return nil
+}
+
+// MetricNames retorna as métricas disponíveis em ordem alfabética
+func MetricNames() []string {
+	names := make([]string, 0, len(alertMetrics))
+	for name := range alertMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Evaluate aplica as regras aos dados extraídos. Regras de variação (delta ou
+// percent) só são avaliadas quando há um snapshot anterior, com sua análise.
+// Regras sobre métricas sem dados são registradas no log e ignoradas.
+func Evaluate(rules []Rule, data *extractor.RepositoryData, analysis *utils.Analysis,
+	previous *extractor.RepositoryData, previousAnalysis *utils.Analysis) []*Alert {
+	var alerts []*Alert
+	for _, rule := range rules {
+		metric := alertMetrics[rule.Metric]
+		current := metric.value(data, analysis)
+		if math.IsNaN(current) {
+			log.Printf("ℹ️ Regra %q não avaliada para %s: sem dados para %s", rule.Name, data.BasicInfo.FullName, rule.Metric)
+			continue
+		}
+		compared := current
+
+		var before *float64
+		if rule.Change != ChangeNone {
+			if previous == nil {
+				continue
+			}
+			value := metric.value(previous, previousAnalysis)
+			if math.IsNaN(value) {
+				continue
+			}
+			before = &value
+			compared = current - value
+			if rule.Change == ChangePercent {
+				if value == 0 {
+					continue
+				}
+				compared = compared / math.Abs(value) * 100
+			}
+		}
+
+		if !operators[rule.Op](compared, rule.Value) {
+			continue
+		}
+
+		alerts = append(alerts, &Alert{
+			Rule:        rule.Name,
+			Repository:  data.BasicInfo.FullName,
+			URL:         data.BasicInfo.URL,
+			Metric:      rule.Metric,
+			Value:       current,
+			Previous:    before,
+			Threshold:   rule.Value,
+			Severity:    severityOrDefault(rule.Severity),
+			Message:     rule.message(metric.label, current, before, compared),
+			TriggeredAt: time.Now(),
+		})
+	}
+	return alerts
+}
+
+// message descreve o alerta em uma linha
+func (r Rule) message(label string, current float64, previous *float64, compared float64) string {
+	switch r.Change {
+	case ChangeDelta:
+		return fmt.Sprintf("%s: %s variou %+g (de %g para %g; regra: variação %s %g)",
+			r.Name, label, compared, *previous, current, r.Op, r.Value)
+	case ChangePercent:
+		return fmt.Sprintf("%s: %s variou %+.1f%% (de %g para %g; regra: variação %s %g%%)",
+			r.Name, label, compared, *previous, current, r.Op, r.Value)
+	default:
+		return fmt.Sprintf("%s: %s = %s (regra: %s %g)", r.Name, label, formatValue(current), r.Op, r.Value)
+	}
+}
+
+// formatValue formata valores inteiros sem casas decimais
+func formatValue(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+// severityOrDefault usa "warning" quando a regra não define severidade
+func severityOrDefault(severity string) string {
+	if severity == "" {
+		return "warning"
+	}
+	return severity
+}
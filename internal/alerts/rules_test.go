@@ -0,0 +1,57 @@
+package alerts
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+func TestEvaluateWithoutData(t *testing.T) {
+	rules := []Rule{
+		{Name: "Sem commits há 30 dias", Metric: "last_commit_days", Op: ">", Value: 30},
+		{Name: "Sem release há 90 dias", Metric: "last_release_days", Op: ">", Value: 90},
+		{Name: "Commit recente", Metric: "last_commit_days", Op: "!=", Value: 30},
+	}
+	old := time.Now().AddDate(0, 0, -120)
+
+	tests := []struct {
+		name     string
+		commits  []*extractor.CommitData
+		releases []*extractor.ReleaseData
+		health   *utils.RepositoryHealth
+		want     []string
+	}{
+		{"sem commits nem releases", nil, nil, &utils.RepositoryHealth{}, nil},
+		{"commit e release antigos",
+			[]*extractor.CommitData{{SHA: "abc", CreatedAt: old}},
+			[]*extractor.ReleaseData{{TagName: "v1.0.0", PublishedAt: old}},
+			&utils.RepositoryHealth{LastCommitDays: 120, LastReleaseDays: 120},
+			[]string{"Sem commits há 30 dias", "Sem release há 90 dias", "Commit recente"}},
+		{"commit antigo sem releases",
+			[]*extractor.CommitData{{SHA: "abc", CreatedAt: old}}, nil,
+			&utils.RepositoryHealth{LastCommitDays: 120},
+			[]string{"Sem commits há 30 dias", "Commit recente"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &extractor.RepositoryData{
+				BasicInfo:     &extractor.BasicInfo{FullName: "octo/app"},
+				RecentCommits: tt.commits,
+				Releases:      tt.releases,
+			}
+			alerts := Evaluate(rules, data, &utils.Analysis{Health: tt.health}, nil, nil)
+
+			var got []string
+			for _, alert := range alerts {
+				got = append(got, alert.Rule)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("alertas = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
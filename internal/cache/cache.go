@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// Cache armazena em memória os dados extraídos de cada repositório. Com um
+// limite de itens, os menos usados recentemente são descartados primeiro.
+type Cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	// order mantém os itens do usado mais recentemente para o menos usado
+	order *list.List
+}
+
+// entry representa um item armazenado no cache
+type entry struct {
+	key      string
+	data     *extractor.RepositoryData
+	storedAt time.Time
+}
+
+// New cria um novo cache sem limite de itens; ttl igual a zero significa que
+// os itens não expiram
+func New(ttl time.Duration) *Cache {
+	return NewWithLimit(ttl, 0)
+}
+
+// NewWithLimit cria um cache com no máximo maxEntries itens (zero: sem limite)
+func NewWithLimit(ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get retorna os dados de um repositório se estiverem no cache e válidos;
+// itens expirados são removidos
+func (c *Cache) Get(owner, repo string) (*extractor.RepositoryData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[Key(owner, repo)]
+	if !ok {
+		return nil, false
+	}
+	item := element.Value.(*entry)
+	if c.expired(item) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return item.data, true
+}
+
+// Set armazena os dados de um repositório no cache, renovando a validade
+func (c *Cache) Set(owner, repo string, data *extractor.RepositoryData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := Key(owner, repo)
+	if element, ok := c.entries[key]; ok {
+		item := element.Value.(*entry)
+		item.data, item.storedAt = data, time.Now()
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, data: data, storedAt: time.Now()})
+	c.evict()
+}
+
+// Update substitui os dados de um repositório já em cache sem renovar a
+// validade, para que atualizações parciais não adiem a próxima extração
+// completa; retorna false quando o repositório não está em cache ou expirou
+func (c *Cache) Update(owner, repo string, data *extractor.RepositoryData) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[Key(owner, repo)]
+	if !ok {
+		return false
+	}
+	item := element.Value.(*entry)
+	if c.expired(item) {
+		c.remove(element)
+		return false
+	}
+	item.data = data
+	return true
+}
+
+// Delete remove um repositório do cache
+func (c *Cache) Delete(owner, repo string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[Key(owner, repo)]; ok {
+		c.remove(element)
+	}
+}
+
+// Len retorna a quantidade de itens armazenados
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// evict descarta itens expirados e, acima do limite, os menos usados
+func (c *Cache) evict() {
+	if c.ttl > 0 {
+		for element := c.order.Back(); element != nil; {
+			previous := element.Prev()
+			if c.expired(element.Value.(*entry)) {
+				c.remove(element)
+			}
+			element = previous
+		}
+	}
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove apaga o item da lista e do índice
+func (c *Cache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry).key)
+}
+
+// expired verifica se um item ultrapassou o TTL configurado
+func (c *Cache) expired(item *entry) bool {
+	return c.ttl > 0 && time.Since(item.storedAt) > c.ttl
+}
+
+// Key gera a chave do cache; nomes no GitHub não diferenciam maiúsculas
+func Key(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
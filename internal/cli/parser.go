@@ -6,14 +6,47 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 )
 
+// Subcomandos suportados
+const (
+	CommandAnalyze      = "analyze"
+	CommandCompare      = "compare"
+	CommandChangelog    = "changelog"
+	CommandServeMetrics = "serve-metrics"
+	CommandServe        = "serve"
+	CommandDaemon       = "daemon"
+)
+
+// commands lista os subcomandos reconhecidos como primeiro argumento
+var commands = map[string]bool{
+	CommandAnalyze:      true,
+	CommandCompare:      true,
+	CommandChangelog:    true,
+	CommandServeMetrics: true,
+	CommandServe:        true,
+	CommandDaemon:       true,
+}
+
 // Args representa os argumentos da linha de comando
 type Args struct {
+	Command     string
+	Targets     []Target
+	From        string
+	To          string
 	RepoURL     string
 	Owner       string
 	Repo        string
 	OutputDir   string
+	BatchFile   string
+	HTML        bool
+	Formats     string
+	Addr        string
+	Interval    time.Duration
+	CacheTTL    time.Duration
+	Schedule    string
+	RunNow      bool
 	ShowHelp    bool
 	ShowVersion bool
 }
@@ -29,7 +62,17 @@ func Parse() (*Args, error) {
 	flag.StringVar(&args.Owner, "o", "", "Proprietário do repositório (formato curto)")
 	flag.StringVar(&args.Repo, "repo", "", "Nome do repositório")
 	flag.StringVar(&args.Repo, "r", "", "Nome do repositório (formato curto)")
-	flag.StringVar(&args.OutputDir, "output", "output", "Diretório de saída")
+	flag.StringVar(&args.OutputDir, "output", "", "Diretório de saída")
+	flag.StringVar(&args.BatchFile, "batch", "", "Arquivo com lista de repositórios (txt, csv ou yaml)")
+	flag.StringVar(&args.BatchFile, "b", "", "Arquivo com lista de repositórios (formato curto)")
+	flag.BoolVar(&args.HTML, "html", false, "Gerar também o dashboard HTML autocontido")
+	flag.StringVar(&args.Formats, "format", "", "Formatos de saída separados por vírgula (json,txt,md,html,csv,ndjson,prom,cyclonedx,spdx)")
+	flag.StringVar(&args.Formats, "f", "", "Formatos de saída (formato curto)")
+	flag.StringVar(&args.Addr, "addr", "", "Endereço HTTP dos modos servidor (ex: :9090)")
+	flag.DurationVar(&args.Interval, "interval", 0, "Intervalo de atualização do serve-metrics (ex: 15m)")
+	flag.DurationVar(&args.CacheTTL, "cache-ttl", 0, "Validade do cache de análises do serve (ex: 10m)")
+	flag.StringVar(&args.Schedule, "schedule", "", "Expressão cron do daemon (ex: \"0 */6 * * *\")")
+	flag.BoolVar(&args.RunNow, "run-now", false, "Executar uma rodada do daemon imediatamente ao iniciar")
 	flag.BoolVar(&args.ShowHelp, "help", false, "Mostrar ajuda")
 	flag.BoolVar(&args.ShowHelp, "h", false, "Mostrar ajuda (formato curto)")
 	flag.BoolVar(&args.ShowVersion, "version", false, "Mostrar versão")
@@ -40,8 +83,16 @@ func Parse() (*Args, error) {
 		showUsage()
 	}
 
-	// Parse dos argumentos
-	flag.Parse()
+	// Identificar subcomando (analyze é o padrão)
+	arguments := os.Args[1:]
+	args.Command = CommandAnalyze
+	if len(arguments) > 0 && commands[arguments[0]] {
+		args.Command = arguments[0]
+		arguments = arguments[1:]
+	}
+
+	// Parse dos argumentos, permitindo flags depois dos posicionais
+	positionalArgs := parseInterspersed(flag.CommandLine, arguments)
 
 	// Verificar se precisa mostrar help ou version
 	if args.ShowHelp {
@@ -54,9 +105,32 @@ func Parse() (*Args, error) {
 		os.Exit(0)
 	}
 
+	// Comparação recebe 2 ou mais repositórios posicionais
+	if args.Command == CommandCompare {
+		return parseTargets(args, positionalArgs, 2)
+	}
+
+	// Changelog recebe o repositório e, opcionalmente, as refs de origem e destino
+	if args.Command == CommandChangelog {
+		return parseChangelog(args, positionalArgs)
+	}
+
+	// Modos servidor aceitam repositórios posicionais e/ou --batch
+	if args.Command == CommandServeMetrics || args.Command == CommandDaemon {
+		return parseTargets(args, positionalArgs, 0)
+	}
+
+	// A API HTTP recebe os repositórios pela URL das requisições; --batch define a allowlist
+	if args.Command == CommandServe {
+		if len(positionalArgs) > 0 {
+			return nil, fmt.Errorf("o comando %s não aceita repositórios como argumento (recebido: %s)",
+				args.Command, strings.Join(positionalArgs, " "))
+		}
+		return args, nil
+	}
+
 	// Se não há argumentos, verificar se tem argumentos posicionais
 	if args.RepoURL == "" && args.Owner == "" && args.Repo == "" {
-		positionalArgs := flag.Args()
 		if len(positionalArgs) > 0 {
 			args.RepoURL = positionalArgs[0]
 		}
@@ -75,6 +149,63 @@ func Parse() (*Args, error) {
 	return args, nil
 }
 
+// parseTargets converte os argumentos posicionais em alvos do subcomando,
+// exigindo uma quantidade mínima de repositórios
+func parseTargets(args *Args, positionalArgs []string, min int) (*Args, error) {
+	for _, ref := range positionalArgs {
+		target, err := ParseTarget(ref)
+		if err != nil {
+			return nil, err
+		}
+		args.Targets = append(args.Targets, target)
+	}
+
+	if len(args.Targets) < min {
+		return nil, fmt.Errorf("o comando %s precisa de pelo menos %d repositórios (recebido: %d)",
+			args.Command, min, len(args.Targets))
+	}
+
+	return args, nil
+}
+
+// parseChangelog interpreta "changelog <repo> [de] [até]"; refs omitidas são
+// escolhidas a partir dos releases
+func parseChangelog(args *Args, positionalArgs []string) (*Args, error) {
+	if len(positionalArgs) == 0 || len(positionalArgs) > 3 {
+		return nil, fmt.Errorf("uso: %s <repo> [ref-inicial] [ref-final] (recebido: %d argumentos)",
+			args.Command, len(positionalArgs))
+	}
+
+	target, err := ParseTarget(positionalArgs[0])
+	if err != nil {
+		return nil, err
+	}
+	args.Targets = []Target{target}
+	if len(positionalArgs) > 1 {
+		args.From = positionalArgs[1]
+	}
+	if len(positionalArgs) > 2 {
+		args.To = positionalArgs[2]
+	}
+	return args, nil
+}
+
+// parseInterspersed faz o parse das flags aceitando argumentos posicionais
+// misturados (ex: compare a/b c/d --output /tmp)
+func parseInterspersed(fs *flag.FlagSet, arguments []string) []string {
+	var positional []string
+	for {
+		// Com ExitOnError, erros de parse encerram o programa
+		_ = fs.Parse(arguments)
+		arguments = fs.Args()
+		if len(arguments) == 0 {
+			return positional
+		}
+		positional = append(positional, arguments[0])
+		arguments = arguments[1:]
+	}
+}
+
 // parseGitHubURL extrai owner e repo de uma URL do GitHub
 func parseGitHubURL(url string) (owner, repo string, err error) {
 	// Limpar a URL
@@ -109,6 +240,11 @@ func parseGitHubURL(url string) (owner, repo string, err error) {
 	return "", "", fmt.Errorf("formato de URL inválido: %s\n\nFormatos suportados:\n  - https://github.com/owner/repo\n  - git@github.com:owner/repo.git\n  - owner/repo", url)
 }
 
+// IsBatch verifica se a execução é em lote a partir de um arquivo
+func (a *Args) IsBatch() bool {
+	return a.BatchFile != ""
+}
+
 // IsEmpty verifica se os argumentos estão vazios
 func (a *Args) IsEmpty() bool {
 	return a.Owner == "" || a.Repo == ""
@@ -129,10 +265,24 @@ DESCRIÇÃO:
 
 USO:
     %s [opções] [url-do-repositório]
+    %s compare [opções] <repo1> <repo2> [repoN...]
+    %s changelog [opções] <repo> [ref-inicial] [ref-final]
+    %s serve-metrics [opções] [repo...] [--batch arquivo]
+    %s serve [opções] [--batch arquivo]
+    %s daemon [opções] [repo...] [--batch arquivo]
 
 ARGUMENTOS:
     url-do-repositório    URL do repositório GitHub a ser analisado
 
+COMANDOS:
+    analyze               Analisa um repositório (padrão)
+    compare               Compara 2 ou mais repositórios lado a lado
+    changelog             Changelog em Markdown entre duas tags ou SHAs
+                          (padrão: do release anterior ao último release estável)
+    serve-metrics         Exporter Prometheus de longa duração em /metrics
+    serve                 API HTTP com análises em /repos/{owner}/{repo}/analysis
+    daemon                Extração agendada (cron) com snapshots e status
+
 OPÇÕES:
     -u, --url string     URL do repositório GitHub
                          (ex: https://github.com/kubernetes/kubernetes)
@@ -140,7 +290,23 @@ OPÇÕES:
     -o, --owner string   Proprietário do repositório
     -r, --repo string    Nome do repositório
     
-    --output string      Diretório de saída (padrão: "output")
+    --output string      Diretório de saída (padrão: OUTPUT_DIR ou "output")
+
+    -b, --batch string   Arquivo com lista de repositórios (txt, csv ou yaml)
+
+    -f, --format string  Formatos de saída separados por vírgula
+                         (json, txt, md, html, csv, ndjson, prom, cyclonedx, spdx; padrão: OUTPUT_FORMATS ou "json,txt,md")
+    --html               Gerar também o dashboard HTML (com gráficos SVG, funciona offline)
+
+    --addr string        Endereço HTTP dos modos servidor
+                         (padrão: METRICS_ADDR ou ":9090"; no serve, SERVER_ADDR ou ":8080";
+                         no daemon, DAEMON_ADDR, que habilita o endpoint /status)
+    --interval duration  Intervalo de atualização do serve-metrics
+                         (padrão: METRICS_INTERVAL ou 15m)
+    --cache-ttl duration Validade do cache de análises do serve
+                         (padrão: CACHE_TTL ou 10m)
+    --schedule string    Expressão cron do daemon (padrão: DAEMON_SCHEDULE ou "0 * * * *")
+    --run-now            Executar uma rodada do daemon imediatamente ao iniciar
     
     -h, --help          Mostrar esta ajuda
     -v, --version       Mostrar versão
@@ -155,12 +321,36 @@ EXEMPLOS:
     # Analisar usando flags separadas
     %s -o kubernetes -r kubernetes
     
+    # Exportar CSVs para planilhas junto com o JSON
+    %s kubernetes/kubernetes --format json,csv
+
     # Analisar com diretório de saída customizado
     %s -u https://github.com/kubernetes/kubernetes --output /tmp/analise
     
     # URL SSH também funciona
     %s git@github.com:kubernetes/kubernetes.git
 
+    # Analisar uma lista de repositórios em lote
+    %s --batch repos.txt
+
+    # Comparar repositórios lado a lado
+    %s compare gin-gonic/gin labstack/echo gofiber/fiber
+
+    # Changelog entre duas tags (sem refs: os dois últimos releases)
+    %s changelog cli/cli v2.40.0 v2.41.0
+
+    # Mudanças ainda não lançadas desde o último release
+    %s changelog cli/cli v2.41.0 trunk
+
+    # Expor métricas Prometheus atualizadas a cada 10 minutos
+    %s serve-metrics --batch repos.txt --addr :9090 --interval 10m
+
+    # API HTTP com cache de 5 minutos (GET /repos/owner/repo/report?format=md)
+    %s serve --addr :8080 --cache-ttl 5m --batch repos.txt
+
+    # Snapshots a cada 6 horas, com status em http://localhost:8081/status
+    %s daemon --batch repos.txt --schedule "0 */6 * * *" --addr :8081
+
 FORMATOS DE URL SUPORTADOS:
     ✅ https://github.com/owner/repo
     ✅ https://github.com/owner/repo.git
@@ -175,7 +365,7 @@ CONFIGURAÇÃO:
     GITHUB_DEFAULT_REPO=repo_padrao
 
 Para mais informações, visite: https://github.com/seu-usuario/github-octokit-poc
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
 
 // showVersion exibe a versão
@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Target representa um repositório a ser analisado
+type Target struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Source string `json:"source"`
+}
+
+// FullName retorna o nome completo no formato owner/repo
+func (t Target) FullName() string {
+	return t.Owner + "/" + t.Repo
+}
+
+// ParseTarget converte uma referência (URL, SSH ou owner/repo) em Target
+func ParseTarget(ref string) (Target, error) {
+	owner, repo, err := parseGitHubURL(ref)
+	if err != nil {
+		return Target{}, err
+	}
+	return Target{Owner: owner, Repo: repo, Source: strings.TrimSpace(ref)}, nil
+}
+
+// LoadTargetsFile lê uma lista de repositórios de um arquivo texto, CSV ou YAML.
+// O formato é escolhido pela extensão do arquivo; qualquer outra extensão é
+// tratada como texto simples (um repositório por linha).
+func LoadTargetsFile(path string) ([]Target, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir arquivo de repositórios: %v", err)
+	}
+	defer file.Close()
+
+	var refs []lineRef
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		refs, err = readCSVRefs(file)
+	case ".yaml", ".yml":
+		refs, err = readYAMLRefs(file)
+	default:
+		refs, err = readTextRefs(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %v", path, err)
+	}
+
+	targets := make([]Target, 0, len(refs))
+	for _, ref := range refs {
+		target, err := ParseTarget(ref.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, ref.line, err)
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("nenhum repositório encontrado em %s", path)
+	}
+
+	return targets, nil
+}
+
+// lineRef guarda uma referência de repositório e a linha onde foi encontrada
+type lineRef struct {
+	value string
+	line  int
+}
+
+// readTextRefs lê um repositório por linha, ignorando linhas vazias e comentários (#)
+func readTextRefs(r io.Reader) ([]lineRef, error) {
+	var refs []lineRef
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		value := stripComment(scanner.Text())
+		if value == "" {
+			continue
+		}
+		refs = append(refs, lineRef{value: value, line: line})
+	}
+	return refs, scanner.Err()
+}
+
+// readCSVRefs lê um CSV usando a coluna "repo", "repository" ou "url" do
+// cabeçalho; sem cabeçalho reconhecido, usa a primeira coluna
+func readCSVRefs(r io.Reader) ([]lineRef, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	var refs []lineRef
+	column := 0
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if first {
+			first = false
+			if idx := csvRefColumn(record); idx >= 0 {
+				column = idx
+				continue
+			}
+		}
+
+		if column >= len(record) {
+			continue
+		}
+		value := strings.TrimSpace(record[column])
+		if value == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(column)
+		refs = append(refs, lineRef{value: value, line: line})
+	}
+	return refs, nil
+}
+
+// csvRefColumn retorna o índice da coluna de repositório no cabeçalho ou -1
+func csvRefColumn(header []string) int {
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "repo", "repository", "repositorio", "url":
+			return i
+		}
+	}
+	return -1
+}
+
+// readYAMLRefs lê listas YAML simples, como:
+//
+//	repos:
+//	  - kubernetes/kubernetes
+//	  - url: https://github.com/golang/go
+//	  - name: cli
+//	    repo: cli/cli
+//
+// Apenas itens de lista são considerados; itens de mapa, em uma ou mais
+// linhas, precisam de uma chave url, repo ou repository.
+func readYAMLRefs(r io.Reader) ([]lineRef, error) {
+	var refs []lineRef
+	// yamlItem é um item de mapa aberto: linha e indentação do "-" e se a
+	// chave do repositório já apareceu
+	type yamlItem struct {
+		line, indent int
+		found        bool
+	}
+	var item *yamlItem
+	closeItem := func() error {
+		if item != nil && !item.found {
+			return fmt.Errorf("linha %d: item sem a chave url, repo ou repository", item.line)
+		}
+		item = nil
+		return nil
+	}
+	addRef := func(value string, line int) {
+		if value = strings.Trim(strings.TrimSpace(value), `"'`); value != "" {
+			refs = append(refs, lineRef{value: value, line: line})
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		value := stripComment(text)
+		if value == "" {
+			continue
+		}
+		indent := len(text) - len(strings.TrimLeft(text, " \t"))
+
+		// Linhas mais indentadas que o "-" continuam o item de mapa aberto
+		if item != nil && indent > item.indent {
+			if key, rest, ok := yamlKeyValue(value); ok && isYAMLRefKey(key) && !item.found {
+				item.found = true
+				addRef(rest, line)
+			}
+			continue
+		}
+		if err := closeItem(); err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(value, "-") {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimPrefix(value, "-"))
+
+		key, rest, ok := yamlKeyValue(value)
+		if !ok {
+			addRef(value, line)
+			continue
+		}
+		item = &yamlItem{line: line, indent: indent, found: isYAMLRefKey(key)}
+		if item.found {
+			addRef(rest, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := closeItem(); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// yamlKeyValue separa um par "chave: valor"; URLs e refs SSH (https://...,
+// git@github.com:owner/repo) não são pares, pois o ":" não é seguido de espaço
+func yamlKeyValue(value string) (string, string, bool) {
+	key, rest, found := strings.Cut(value, ":")
+	if !found || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), rest, true
+}
+
+// isYAMLRefKey verifica se a chave YAML identifica o repositório
+func isYAMLRefKey(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "repo", "repository", "url":
+		return true
+	}
+	return false
+}
+
+// stripComment remove comentários iniciados por # e espaços nas extremidades
+func stripComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	if idx := strings.Index(line, " #"); idx >= 0 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
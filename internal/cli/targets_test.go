@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadYAMLRefs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []lineRef
+		err   string
+	}{
+		{
+			name:  "itens simples",
+			input: "repos:\n  - kubernetes/kubernetes\n  - \"https://github.com/golang/go\"\n  - git@github.com:cli/cli.git # ssh\n",
+			want:  []lineRef{{"kubernetes/kubernetes", 2}, {"https://github.com/golang/go", 3}, {"git@github.com:cli/cli.git", 4}},
+		},
+		{
+			name:  "mapas em uma linha",
+			input: "- url: https://github.com/golang/go\n- repo: cli/cli\n",
+			want:  []lineRef{{"https://github.com/golang/go", 1}, {"cli/cli", 2}},
+		},
+		{
+			name:  "mapas em várias linhas",
+			input: "repos:\n  - name: cli\n    # comentário\n    repo: cli/cli\n    tags:\n      - go\n  - repository: golang/go\n    name: go\n",
+			want:  []lineRef{{"cli/cli", 4}, {"golang/go", 7}},
+		},
+		{
+			name:  "mapa sem a chave do repositório",
+			input: "repos:\n  - kubernetes/kubernetes\n  - name: foo\n    owner: a\n  - cli/cli\n",
+			err:   "linha 3:",
+		},
+		{
+			name:  "mapa sem a chave no fim do arquivo",
+			input: "- name: foo\n",
+			err:   "linha 1:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs, err := readYAMLRefs(strings.NewReader(tt.input))
+			if tt.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+					t.Fatalf("erro = %v, esperado %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if !slices.Equal(refs, tt.want) {
+				t.Errorf("refs = %v, esperado %v", refs, tt.want)
+			}
+		})
+	}
+}
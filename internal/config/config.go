@@ -1,17 +1,55 @@
 package config
 
 import (
+	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config representa as configurações da aplicação
 type Config struct {
-	DefaultOwner string
-	DefaultRepo  string
-	OutputDir    string
-	Debug        bool
+	DefaultOwner  string
+	DefaultRepo   string
+	OutputDir     string
+	OutputFormats string
+	TextfileDir   string
+	Debug         bool
+
+	// Modelo do score de saúde
+	HealthModelFile string
+
+	// Exporter Prometheus (serve-metrics)
+	MetricsAddr     string
+	MetricsInterval time.Duration
+
+	// API HTTP (serve)
+	ServerAddr      string
+	CacheTTL        time.Duration
+	CacheSize       int
+	ServerAllowlist []string
+	WebhookSecret   string
+
+	// Execução agendada (daemon)
+	SnapshotDir      string
+	DaemonSchedule   string
+	DaemonStatusFile string
+	DaemonAddr       string
+	RateLimitReserve int
+
+	// Alertas
+	AlertRulesFile    string
+	AlertWebhookURL   string
+	AlertSlackURL     string
+	AlertSMTPAddr     string
+	AlertSMTPFrom     string
+	AlertSMTPTo       string
+	AlertSMTPUsername string
+	AlertSMTPPassword string
 }
 
 // Load carrega as configurações do .env e variáveis de ambiente
@@ -21,11 +59,41 @@ func Load() (*Config, error) {
 		// Não é erro crítico, pode usar variáveis de ambiente do sistema
 	}
 
+	snapshotDir := getEnvOrDefault("SNAPSHOT_DIR", "snapshots")
+
 	return &Config{
-		DefaultOwner: getEnvOrDefault("GITHUB_DEFAULT_USER", "kubernetes"),
-		DefaultRepo:  getEnvOrDefault("GITHUB_DEFAULT_REPO", "kubernetes"),
-		OutputDir:    getEnvOrDefault("OUTPUT_DIR", "output"),
-		Debug:        os.Getenv("DEBUG") == "true",
+		DefaultOwner:  getEnvOrDefault("GITHUB_DEFAULT_USER", "kubernetes"),
+		DefaultRepo:   getEnvOrDefault("GITHUB_DEFAULT_REPO", "kubernetes"),
+		OutputDir:     getEnvOrDefault("OUTPUT_DIR", "output"),
+		OutputFormats: os.Getenv("OUTPUT_FORMATS"),
+		TextfileDir:   os.Getenv("TEXTFILE_COLLECTOR_DIR"),
+		Debug:         os.Getenv("DEBUG") == "true",
+
+		HealthModelFile: os.Getenv("HEALTH_MODEL_FILE"),
+
+		MetricsAddr:     getEnvOrDefault("METRICS_ADDR", ":9090"),
+		MetricsInterval: getDurationOrDefault("METRICS_INTERVAL", 15*time.Minute),
+
+		ServerAddr:      getEnvOrDefault("SERVER_ADDR", ":8080"),
+		CacheTTL:        getDurationOrDefault("CACHE_TTL", 10*time.Minute),
+		CacheSize:       getIntOrDefault("CACHE_SIZE", 100),
+		ServerAllowlist: getList("SERVER_ALLOWLIST"),
+		WebhookSecret:   os.Getenv("GITHUB_WEBHOOK_SECRET"),
+
+		SnapshotDir:      snapshotDir,
+		DaemonSchedule:   getEnvOrDefault("DAEMON_SCHEDULE", "0 * * * *"),
+		DaemonStatusFile: getEnvOrDefault("DAEMON_STATUS_FILE", filepath.Join(snapshotDir, "status.json")),
+		DaemonAddr:       os.Getenv("DAEMON_ADDR"),
+		RateLimitReserve: getIntOrDefault("RATE_LIMIT_RESERVE", 500),
+
+		AlertRulesFile:    os.Getenv("ALERT_RULES_FILE"),
+		AlertWebhookURL:   os.Getenv("ALERT_WEBHOOK_URL"),
+		AlertSlackURL:     os.Getenv("ALERT_SLACK_WEBHOOK_URL"),
+		AlertSMTPAddr:     os.Getenv("ALERT_SMTP_ADDR"),
+		AlertSMTPFrom:     os.Getenv("ALERT_SMTP_FROM"),
+		AlertSMTPTo:       os.Getenv("ALERT_SMTP_TO"),
+		AlertSMTPUsername: os.Getenv("ALERT_SMTP_USERNAME"),
+		AlertSMTPPassword: os.Getenv("ALERT_SMTP_PASSWORD"),
 	}, nil
 }
 
@@ -42,4 +110,48 @@ func getEnvOrDefault(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getDurationOrDefault lê uma duração (ex: "15m") da variável de ambiente,
+// usando o valor padrão quando ausente ou inválida
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("⚠️ Valor inválido para %s (%q), usando %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// getIntOrDefault lê um inteiro não negativo da variável de ambiente, usando
+// o valor padrão quando ausente ou inválido
+func getIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("⚠️ Valor inválido para %s (%q), usando %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getList lê uma lista separada por vírgulas da variável de
+// ambiente, ignorando itens vazios
+func getList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
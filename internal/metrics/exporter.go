@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github-octokit-poc/extractor"
+	ghclient "github-octokit-poc/github"
+	"github-octokit-poc/internal/cache"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/utils"
+)
+
+// exporterDefinitions define as métricas sobre o próprio exporter
+var exporterDefinitions = struct {
+	up, errors, duration Definition
+}{
+	Definition{"github_exporter_up", "1 se a última atualização do repositório teve sucesso.", TypeGauge},
+	Definition{"github_exporter_refresh_errors_total", "Total de falhas ao atualizar o repositório.", TypeCounter},
+	Definition{"github_exporter_refresh_duration_seconds", "Duração da última atualização do repositório.", TypeGauge},
+}
+
+// repoStatus guarda o estado da última atualização de um repositório
+type repoStatus struct {
+	up       bool
+	errors   int
+	duration time.Duration
+}
+
+// Exporter atualiza periodicamente uma lista de repositórios e expõe as
+// métricas no formato texto do Prometheus
+type Exporter struct {
+	client   *ghclient.Client
+	targets  []cli.Target
+	interval time.Duration
+	analyzer utils.Analyzer
+	cache    *cache.Cache
+
+	mu     sync.RWMutex
+	status map[string]*repoStatus
+}
+
+// NewExporter cria um exporter para os repositórios informados; repositórios
+// repetidos são considerados apenas uma vez e analyzer define as opções das
+// análises que geram as métricas
+func NewExporter(client *ghclient.Client, targets []cli.Target, interval time.Duration, analyzer utils.Analyzer) *Exporter {
+	status := make(map[string]*repoStatus, len(targets))
+	var unique []cli.Target
+	for _, target := range targets {
+		key := cache.Key(target.Owner, target.Repo)
+		if _, exists := status[key]; exists {
+			continue
+		}
+		status[key] = &repoStatus{}
+		unique = append(unique, target)
+	}
+
+	return &Exporter{
+		client:   client,
+		targets:  unique,
+		interval: interval,
+		analyzer: analyzer,
+		cache:    cache.New(0),
+		status:   status,
+	}
+}
+
+// Run atualiza todos os repositórios imediatamente e depois a cada intervalo,
+// até o contexto ser cancelado
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.refreshAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshAll extrai novamente cada repositório, mantendo os últimos dados
+// válidos em caso de falha
+func (e *Exporter) refreshAll(ctx context.Context) {
+	log.Printf("🔄 Atualizando métricas de %d repositórios", len(e.targets))
+
+	for _, target := range e.targets {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		data, err := extractor.ExtractRepositoryData(e.client, target.Owner, target.Repo)
+		duration := time.Since(start)
+
+		e.mu.Lock()
+		status := e.status[cache.Key(target.Owner, target.Repo)]
+		status.duration = duration
+		if err != nil {
+			status.up = false
+			status.errors++
+			log.Printf("⚠️ Erro ao atualizar %s: %v", target.FullName(), err)
+		} else {
+			status.up = true
+			e.cache.Set(target.Owner, target.Repo, data)
+		}
+		e.mu.Unlock()
+	}
+}
+
+// ServeHTTP expõe as métricas no formato texto do Prometheus
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := WriteText(&buf, e.Families()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// Families retorna as métricas atuais dos repositórios e do exporter
+func (e *Exporter) Families() []*Family {
+	var datasets []*extractor.RepositoryData
+	for _, target := range e.targets {
+		if data, ok := e.cache.Get(target.Owner, target.Repo); ok {
+			datasets = append(datasets, data)
+		}
+	}
+	families := RepositoryFamilies(datasets, e.analyzer)
+
+	up := &Family{Definition: exporterDefinitions.up}
+	errors := &Family{Definition: exporterDefinitions.errors}
+	duration := &Family{Definition: exporterDefinitions.duration}
+
+	e.mu.RLock()
+	for _, target := range e.targets {
+		status := e.status[cache.Key(target.Owner, target.Repo)]
+		labels := RepoLabels(target.Owner, target.Repo)
+		up.Samples = append(up.Samples, Sample{Labels: labels, Value: boolValue(status.up)})
+		errors.Samples = append(errors.Samples, Sample{Labels: labels, Value: float64(status.errors)})
+		duration.Samples = append(duration.Samples, Sample{Labels: labels, Value: status.duration.Seconds()})
+	}
+	e.mu.RUnlock()
+
+	return append(families, up, errors, duration)
+}
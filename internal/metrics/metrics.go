@@ -0,0 +1,409 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Tipos de métrica do formato texto do Prometheus
+const (
+	TypeGauge   = "gauge"
+	TypeCounter = "counter"
+)
+
+// Definition descreve uma métrica (nome, ajuda e tipo)
+type Definition struct {
+	Name string
+	Help string
+	Type string
+}
+
+// Sample representa o valor de uma métrica com seus labels
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Family agrupa as amostras de uma mesma métrica
+type Family struct {
+	Definition
+	Samples []Sample
+}
+
+// repoMetric associa uma definição à função que extrai o valor por repositório
+type repoMetric struct {
+	Definition
+	value func(data *extractor.RepositoryData, analysis *utils.Analysis) float64
+}
+
+// repoMetrics define o conjunto de métricas por repositório (owner/repo)
+var repoMetrics = []repoMetric{
+	{Definition{"github_repo_stars", "Número de stars do repositório.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Stars) }},
+	{Definition{"github_repo_forks", "Número de forks do repositório.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Forks) }},
+	{Definition{"github_repo_watchers", "Número de watchers do repositório.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Watchers) }},
+	{Definition{"github_repo_subscribers", "Número de inscritos do repositório.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Subscribers) }},
+	{Definition{"github_repo_open_issues", "Issues e PRs abertos informados pela API.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.Statistics.Issues) }},
+	{Definition{"github_repo_size_kilobytes", "Tamanho do repositório em KB.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return float64(d.BasicInfo.Size) }},
+	{Definition{"github_repo_archived", "1 se o repositório está arquivado.", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 {
+			return boolValue(d.Settings != nil && d.Settings.Archived)
+		}},
+	{Definition{"github_repo_pushed_timestamp_seconds", "Data do último push (Unix).", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 { return unixSeconds(d.BasicInfo.PushedAt) }},
+	{Definition{"github_repo_health_score", "Score de saúde do repositório (0-100).", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return a.Health.HealthScore }},
+	{Definition{"github_repo_last_commit_days", "Dias desde o último commit.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.Health.LastCommitDays) }},
+	{Definition{"github_repo_last_release_days", "Dias desde o último release.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.Health.LastReleaseDays) }},
+	{Definition{"github_repo_open_issues_ratio", "Fração de issues abertas na amostra.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return a.Health.OpenIssuesRatio }},
+	{Definition{"github_repo_stale_issues", "Issues abertas sem atividade há mais de 90 dias.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.Health.StaleIssues) }},
+	{Definition{"github_repo_commits_last_week", "Commits na última semana.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Activity.CommitsLastWeek)
+		}},
+	{Definition{"github_repo_commits_last_month", "Commits no último mês.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Activity.CommitsLastMonth)
+		}},
+	{Definition{"github_repo_issues_last_week", "Issues criadas na última semana.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Activity.IssuesLastWeek)
+		}},
+	{Definition{"github_repo_prs_last_week", "Pull requests criados na última semana.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.Activity.PRsLastWeek) }},
+	{Definition{"github_repo_contributors", "Colaboradores encontrados.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Contributors.TotalContributors)
+		}},
+	{Definition{"github_repo_core_team_size", "Colaboradores com 100+ contribuições.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.Contributors.CoreTeamSize)
+		}},
+	{Definition{"github_repo_truck_factor", "Colaboradores que somam mais de 50% dos commits.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return float64(a.BusFactor.TruckFactor) }},
+	{Definition{"github_repo_contributors_gini", "Coeficiente de Gini dos commits por colaborador.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 { return a.BusFactor.Gini }},
+	{Definition{"github_repo_core_committers", "Menor grupo de colaboradores responsável por 80% dos commits.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			return float64(a.BusFactor.CoreCommitters)
+		}},
+	{Definition{"github_repo_commits_last_year", "Commits nas últimas 52 semanas (endpoint de estatísticas).", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Stats == nil {
+				return 0
+			}
+			return float64(a.Stats.CommitsLastYear)
+		}},
+	{Definition{"github_repo_commit_trend_percent", "Variação dos commits das últimas 4 semanas sobre as 4 anteriores.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Stats == nil {
+				return 0
+			}
+			return a.Stats.TrendPercent
+		}},
+	{Definition{"github_repo_community_missing_files", "Arquivos de comunidade ausentes (README, CONTRIBUTING, SECURITY...).", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Community == nil {
+				return 0
+			}
+			return float64(len(a.Community.Missing))
+		}},
+	{Definition{"github_repo_codeowners_coverage_percent", "Percentual de arquivos com dono válido no CODEOWNERS.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.CodeOwners == nil {
+				return 0
+			}
+			return a.CodeOwners.Coverage
+		}},
+	{Definition{"github_repo_codeowners_invalid_owners", "Donos do CODEOWNERS inexistentes ou sem permissão de escrita.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.CodeOwners == nil {
+				return 0
+			}
+			return float64(len(a.CodeOwners.InvalidOwners))
+		}},
+	{Definition{"github_repo_tree_files", "Arquivos no branch padrão (Git Trees API).", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Tree == nil {
+				return 0
+			}
+			return float64(a.Tree.Files)
+		}},
+	{Definition{"github_repo_tested_packages_ratio", "Fração dos pacotes com código-fonte que possuem testes.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Tree == nil {
+				return 0
+			}
+			return a.Tree.TestedShare()
+		}},
+	{Definition{"github_repo_dependencies_total", "Dependências no SBOM e nos manifestos (diretas e indiretas).", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Dependencies == nil {
+				return 0
+			}
+			return float64(a.Dependencies.Total)
+		}},
+	{Definition{"github_repo_direct_dependencies", "Dependências diretas declaradas nos manifestos.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Dependencies == nil {
+				return 0
+			}
+			return float64(a.Dependencies.Direct)
+		}},
+	{Definition{"github_repo_security_alerts_open", "Alertas abertos de Dependabot, code scanning e secret scanning.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Security == nil {
+				return 0
+			}
+			return float64(a.Security.Open)
+		}},
+	{Definition{"github_repo_security_critical_alerts", "Alertas de segurança abertos com severidade crítica.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Security == nil {
+				return 0
+			}
+			return float64(a.Security.Count(extractor.SeverityCritical))
+		}},
+	{Definition{"github_repo_security_score", "Score de segurança (0-100); 100 quando os alertas não são legíveis.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Security == nil {
+				return 100
+			}
+			return a.Security.Score
+		}},
+	{Definition{"github_repo_days_since_stable_release", "Dias desde o último release estável (sem rascunhos e pré-releases).", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Releases == nil {
+				return 0
+			}
+			return float64(a.Releases.DaysSinceStable)
+		}},
+	{Definition{"github_repo_release_interval_median_days", "Mediana dos dias entre releases publicados.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Releases == nil {
+				return 0
+			}
+			return a.Releases.MedianIntervalDays
+		}},
+	{Definition{"github_repo_release_downloads_total", "Downloads dos assets dos releases extraídos.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Downloads == nil {
+				return 0
+			}
+			return float64(a.Downloads.Total)
+		}},
+	{Definition{"github_repo_conventional_commits_ratio", "Fração dos commits recentes (sem merges) no formato Conventional Commits.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Commits == nil {
+				return 0
+			}
+			return a.Commits.ConventionalRatio
+		}},
+	{Definition{"github_repo_verified_commits_ratio", "Fração dos commits recentes com assinatura verificada.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Commits == nil {
+				return 0
+			}
+			return a.Commits.VerifiedRatio
+		}},
+	{Definition{"github_repo_unlinked_commits_ratio", "Fração dos commits recentes (sem merges) sem referência a issue ou PR.", TypeGauge},
+		func(_ *extractor.RepositoryData, a *utils.Analysis) float64 {
+			if a.Commits == nil {
+				return 0
+			}
+			return a.Commits.UnlinkedRatio
+		}},
+	{Definition{"github_repo_extraction_timestamp_seconds", "Momento da última extração (Unix).", TypeGauge},
+		func(d *extractor.RepositoryData, _ *utils.Analysis) float64 {
+			return unixSeconds(d.ExtractionMeta.ExtractedAt)
+		}},
+}
+
+// rateLimitDefinitions define as métricas de rate limit, rotuladas pelo recurso
+var rateLimitDefinitions = struct {
+	remaining, limit, reset Definition
+}{
+	Definition{"github_rate_limit_remaining", "Requisições restantes na janela de rate limit.", TypeGauge},
+	Definition{"github_rate_limit_limit", "Limite de requisições da janela de rate limit.", TypeGauge},
+	Definition{"github_rate_limit_reset_timestamp_seconds", "Momento de reset do rate limit (Unix).", TypeGauge},
+}
+
+// RepositoryFamilies gera as métricas de um conjunto de repositórios. As
+// métricas de rate limit usam os dados do repositório extraído mais recentemente.
+func RepositoryFamilies(datasets []*extractor.RepositoryData, analyzer utils.Analyzer) []*Family {
+	families := newRepositoryFamilies()
+
+	var latest *extractor.RepositoryData
+	for _, data := range datasets {
+		if data == nil || data.BasicInfo == nil || data.Statistics == nil {
+			continue
+		}
+		appendRepositorySamples(families, data, analyzer.Analyze(data))
+
+		if latest == nil || data.ExtractionMeta.ExtractedAt.After(latest.ExtractionMeta.ExtractedAt) {
+			latest = data
+		}
+	}
+
+	if latest != nil {
+		families = append(families, RateLimitFamilies(latest.RateLimit)...)
+	}
+
+	return families
+}
+
+// AnalysisFamilies gera as métricas de um único repositório reaproveitando
+// análises já calculadas, incluindo o rate limit da extração
+func AnalysisFamilies(data *extractor.RepositoryData, analysis *utils.Analysis) []*Family {
+	families := newRepositoryFamilies()
+	appendRepositorySamples(families, data, analysis)
+	return append(families, RateLimitFamilies(data.RateLimit)...)
+}
+
+// newRepositoryFamilies cria as famílias vazias das métricas por repositório
+func newRepositoryFamilies() []*Family {
+	families := make([]*Family, len(repoMetrics))
+	for i, metric := range repoMetrics {
+		families[i] = &Family{Definition: metric.Definition}
+	}
+	return families
+}
+
+// appendRepositorySamples adiciona as amostras de um repositório às famílias
+func appendRepositorySamples(families []*Family, data *extractor.RepositoryData, analysis *utils.Analysis) {
+	labels := RepoLabels(data.ExtractionMeta.Owner, data.ExtractionMeta.Repo)
+	for i, metric := range repoMetrics {
+		families[i].Samples = append(families[i].Samples, Sample{
+			Labels: labels,
+			Value:  metric.value(data, analysis),
+		})
+	}
+}
+
+// RateLimitFamilies gera as métricas de rate limit (core, search e graphql)
+func RateLimitFamilies(rateLimit *extractor.RateLimitData) []*Family {
+	if rateLimit == nil {
+		return nil
+	}
+
+	remaining := &Family{Definition: rateLimitDefinitions.remaining}
+	limit := &Family{Definition: rateLimitDefinitions.limit}
+	reset := &Family{Definition: rateLimitDefinitions.reset}
+
+	resources := []struct {
+		name string
+		rate *github.Rate
+	}{
+		{"core", rateLimit.Core},
+		{"search", rateLimit.Search},
+		{"graphql", rateLimit.GraphQL},
+	}
+	for _, resource := range resources {
+		if resource.rate == nil {
+			continue
+		}
+		labels := map[string]string{"resource": resource.name}
+		remaining.Samples = append(remaining.Samples, Sample{Labels: labels, Value: float64(resource.rate.Remaining)})
+		limit.Samples = append(limit.Samples, Sample{Labels: labels, Value: float64(resource.rate.Limit)})
+		reset.Samples = append(reset.Samples, Sample{Labels: labels, Value: unixSeconds(resource.rate.Reset.Time)})
+	}
+
+	return []*Family{remaining, limit, reset}
+}
+
+// RepoLabels gera os labels padrão de um repositório
+func RepoLabels(owner, repo string) map[string]string {
+	return map[string]string{"owner": owner, "repo": repo}
+}
+
+// WriteText escreve as famílias no formato texto do Prometheus, com as
+// linhas HELP e TYPE de cada métrica
+func WriteText(w io.Writer, families []*Family) error {
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n",
+			family.Name, escapeHelp(family.Help), family.Name, family.Type); err != nil {
+			return err
+		}
+		for _, sample := range family.Samples {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n",
+				family.Name, formatLabels(sample.Labels), formatValue(sample.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// formatLabels formata labels em ordem alfabética: {a="1",b="2"}
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf(`%s="%s"`, key, escapeLabelValue(labels[key]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// escapeLabelValue escapa barra invertida, aspas e quebras de linha
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+// escapeHelp escapa barra invertida e quebras de linha do texto de ajuda
+func escapeHelp(help string) string {
+	help = strings.ReplaceAll(help, `\`, `\\`)
+	return strings.ReplaceAll(help, "\n", `\n`)
+}
+
+// formatValue formata o valor de uma amostra
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// boolValue converte booleanos em 0 ou 1
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// unixSeconds converte datas em segundos Unix; datas vazias viram 0
+func unixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.Unix())
+}
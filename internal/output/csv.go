@@ -0,0 +1,259 @@
+package output
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// csvTable representa o conteúdo de um arquivo CSV de uma coleção
+type csvTable struct {
+	name   string
+	header []string
+	rows   [][]string
+}
+
+// csvWriter registra a exportação CSV no registro de formatos
+type csvWriter struct{}
+
+func (csvWriter) Format() string      { return FormatCSV }
+func (csvWriter) Description() string { return "CSV por coleção" }
+
+func (csvWriter) Write(dir, prefix string, data *extractor.RepositoryData, _ *utils.Analysis) ([]string, error) {
+	return SaveCSV(data, dir, prefix)
+}
+
+// SaveCSV grava um arquivo CSV por coleção de RepositoryData (contributors,
+// issues, pull_requests, releases, release_assets, commits, events e
+// languages) e retorna os caminhos gravados. As colunas têm ordem fixa e
+// datas usam RFC 3339.
+func SaveCSV(data *extractor.RepositoryData, dir, prefix string) ([]string, error) {
+	var files []string
+	var errs []error
+
+	for _, table := range buildCSVTables(data) {
+		path := joinOutputPath(dir, fmt.Sprintf("%s_%s.csv", prefix, table.name))
+		if err := writeCSVFile(path, table); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", table.name, err))
+			continue
+		}
+		files = append(files, path)
+	}
+
+	return files, errors.Join(errs...)
+}
+
+// writeCSVFile grava uma tabela em disco com o escape padrão de CSV
+func writeCSVFile(path string, table csvTable) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(table.header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(table.rows); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// buildCSVTables monta as tabelas de todas as coleções
+func buildCSVTables(data *extractor.RepositoryData) []csvTable {
+	return []csvTable{
+		contributorsCSV(data.Contributors),
+		issuesCSV(data.RecentIssues),
+		pullRequestsCSV(data.RecentPRs),
+		releasesCSV(data.Releases),
+		releaseAssetsCSV(data.Releases),
+		commitsCSV(data.RecentCommits),
+		eventsCSV(data.RecentEvents),
+		languagesCSV(data.Languages),
+	}
+}
+
+func contributorsCSV(contributors []*extractor.Contributor) csvTable {
+	table := csvTable{
+		name:   "contributors",
+		header: []string{"login", "contributions", "type", "avatar_url"},
+	}
+	for _, c := range contributors {
+		table.rows = append(table.rows, []string{
+			c.Login,
+			strconv.Itoa(c.Contributions),
+			c.Type,
+			c.AvatarURL,
+		})
+	}
+	return table
+}
+
+func issuesCSV(issues []*extractor.IssueData) csvTable {
+	table := csvTable{
+		name:   "issues",
+		header: []string{"number", "title", "state", "author", "created_at", "updated_at", "labels", "comments"},
+	}
+	for _, issue := range issues {
+		table.rows = append(table.rows, []string{
+			strconv.Itoa(issue.Number),
+			issue.Title,
+			issue.State,
+			issue.Author,
+			formatCSVTime(issue.CreatedAt),
+			formatCSVTime(issue.UpdatedAt),
+			strings.Join(issue.Labels, ";"),
+			strconv.Itoa(issue.Comments),
+		})
+	}
+	return table
+}
+
+func pullRequestsCSV(prs []*extractor.PullRequestData) csvTable {
+	table := csvTable{
+		name:   "pull_requests",
+		header: []string{"number", "title", "state", "author", "created_at", "updated_at", "merged", "draft"},
+	}
+	for _, pr := range prs {
+		table.rows = append(table.rows, []string{
+			strconv.Itoa(pr.Number),
+			pr.Title,
+			pr.State,
+			pr.Author,
+			formatCSVTime(pr.CreatedAt),
+			formatCSVTime(pr.UpdatedAt),
+			strconv.FormatBool(pr.Merged),
+			strconv.FormatBool(pr.Draft),
+		})
+	}
+	return table
+}
+
+func releasesCSV(releases []*extractor.ReleaseData) csvTable {
+	table := csvTable{
+		name:   "releases",
+		header: []string{"tag_name", "name", "created_at", "published_at", "prerelease", "draft", "author"},
+	}
+	for _, release := range releases {
+		table.rows = append(table.rows, []string{
+			release.TagName,
+			release.Name,
+			formatCSVTime(release.CreatedAt),
+			formatCSVTime(release.PublishedAt),
+			strconv.FormatBool(release.Prerelease),
+			strconv.FormatBool(release.Draft),
+			release.Author,
+		})
+	}
+	return table
+}
+
+func releaseAssetsCSV(releases []*extractor.ReleaseData) csvTable {
+	table := csvTable{
+		name:   "release_assets",
+		header: []string{"tag_name", "name", "pattern", "content_type", "size", "download_count"},
+	}
+	for _, release := range releases {
+		for _, asset := range release.Assets {
+			table.rows = append(table.rows, []string{
+				release.TagName,
+				asset.Name,
+				utils.AssetPattern(asset.Name),
+				asset.ContentType,
+				strconv.Itoa(asset.Size),
+				strconv.Itoa(asset.DownloadCount),
+			})
+		}
+	}
+	return table
+}
+
+func commitsCSV(commits []*extractor.CommitData) csvTable {
+	table := csvTable{
+		name:   "commits",
+		header: []string{"sha", "author", "created_at", "url", "parents", "verified", "verification", "message"},
+	}
+	for _, commit := range commits {
+		table.rows = append(table.rows, []string{
+			commit.SHA,
+			commit.Author,
+			formatCSVTime(commit.CreatedAt),
+			commit.URL,
+			strconv.Itoa(commit.Parents),
+			strconv.FormatBool(commit.Verified),
+			commit.Verification,
+			commit.Message,
+		})
+	}
+	return table
+}
+
+func eventsCSV(events []*extractor.EventData) csvTable {
+	table := csvTable{
+		name:   "events",
+		header: []string{"type", "actor", "created_at", "public"},
+	}
+	for _, event := range events {
+		table.rows = append(table.rows, []string{
+			event.Type,
+			event.Actor,
+			formatCSVTime(event.CreatedAt),
+			strconv.FormatBool(event.Public),
+		})
+	}
+	return table
+}
+
+func languagesCSV(languages map[string]int) csvTable {
+	table := csvTable{
+		name:   "languages",
+		header: []string{"language", "bytes", "percentage"},
+	}
+
+	total := 0
+	names := make([]string, 0, len(languages))
+	for name, bytes := range languages {
+		total += bytes
+		names = append(names, name)
+	}
+
+	// Ordem estável: mais bytes primeiro, empate pelo nome
+	sort.Slice(names, func(i, j int) bool {
+		if languages[names[i]] != languages[names[j]] {
+			return languages[names[i]] > languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		percentage := 0.0
+		if total > 0 {
+			percentage = float64(languages[name]) / float64(total) * 100
+		}
+		table.rows = append(table.rows, []string{
+			name,
+			strconv.Itoa(languages[name]),
+			strconv.FormatFloat(percentage, 'f', 2, 64),
+		})
+	}
+	return table
+}
+
+// formatCSVTime formata datas em RFC 3339 (UTC); datas vazias viram string vazia
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
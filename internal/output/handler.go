@@ -1,13 +1,16 @@
 package output
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
 )
 
 // Handler gerencia a criação e salvamento de arquivos de saída
@@ -16,6 +19,10 @@ type Handler struct {
 	timestamp string
 	owner     string
 	repo      string
+	formats   []string
+	// textfileDir é o diretório monitorado pelo node_exporter
+	// (--collector.textfile.directory); vazio grava apenas na pasta da execução
+	textfileDir string
 }
 
 // NewHandler cria um novo handler de output com diretório padrão
@@ -25,47 +32,116 @@ func NewHandler(owner, repo string) *Handler {
 
 // NewHandlerWithDir cria um novo handler de output com diretório customizado
 func NewHandlerWithDir(owner, repo, baseDir string) *Handler {
-	timestamp := time.Now().Format("20060102_150405")
-	
+	return NewHandlerForRun(owner, repo, baseDir, NewTimestamp())
+}
+
+// NewHandlerForRun cria um handler que grava na pasta de uma execução já
+// existente, permitindo que vários repositórios compartilhem o mesmo diretório
+func NewHandlerForRun(owner, repo, baseDir, timestamp string) *Handler {
 	return &Handler{
 		baseDir:   baseDir,
 		timestamp: timestamp,
 		owner:     owner,
 		repo:      repo,
+		formats:   DefaultFormats,
+	}
+}
+
+// SetFormats define quais formatos registrados serão gravados por SaveAll
+func (h *Handler) SetFormats(formats []string) *Handler {
+	h.formats = formats
+	return h
+}
+
+// SetTextfileDir define o diretório do textfile collector onde o arquivo
+// .prom também será publicado
+func (h *Handler) SetTextfileDir(dir string) *Handler {
+	h.textfileDir = dir
+	return h
+}
+
+// ParseFormats converte uma lista separada por vírgulas (ex: "json,csv") em
+// formatos registrados, rejeitando nomes desconhecidos
+func ParseFormats(value string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		format := strings.ToLower(strings.TrimSpace(part))
+		if format == "" || seen[format] {
+			continue
+		}
+		if _, ok := Lookup(format); !ok {
+			return nil, fmt.Errorf("formato de saída desconhecido: %q (suportados: %s)",
+				format, strings.Join(Formats(), ", "))
+		}
+		seen[format] = true
+		formats = append(formats, format)
 	}
+
+	if len(formats) == 0 {
+		return DefaultFormats, nil
+	}
+	sortByRegistry(formats)
+	return formats, nil
 }
 
-// SaveAll salva todos os outputs (JSON e relatório)
-func (h *Handler) SaveAll(data *extractor.RepositoryData, report string) error {
+// SaveAll grava os outputs de todos os formatos selecionados. Falhas de um
+// formato não impedem os demais; os erros são agregados no retorno.
+func (h *Handler) SaveAll(data *extractor.RepositoryData, analysis *utils.Analysis) error {
 	// Criar estrutura de diretórios
 	outputDir, err := h.createOutputDirectory()
 	if err != nil {
 		return err
 	}
 
-	// Definir caminhos dos arquivos
-	jsonFile := filepath.Join(outputDir, h.getJSONFilename())
-	reportFile := filepath.Join(outputDir, h.getReportFilename())
-
 	log.Printf("\n💾 Salvando arquivos:")
-	log.Printf("   📊 Dados completos: %s", jsonFile)
-	log.Printf("   📋 Relatório: %s", reportFile)
-
-	// Salvar JSON
-	if err := h.saveJSON(data, jsonFile); err != nil {
-		log.Printf("⚠️ Erro ao salvar JSON: %v", err)
-	} else {
-		log.Printf("✅ JSON salvo com sucesso!")
+
+	var errs []error
+	for _, format := range h.formats {
+		writer, ok := Lookup(format)
+		if !ok {
+			errs = append(errs, fmt.Errorf("formato de saída desconhecido: %q", format))
+			continue
+		}
+
+		files, err := writer.Write(outputDir, h.filePrefix(), data, analysis)
+		if err == nil && format == FormatProm && h.textfileDir != "" && len(files) > 0 {
+			var published string
+			published, err = publishTextfile(h.textfileDir, h.filePrefix(), files[0])
+			if err == nil {
+				files = append(files, published)
+			}
+		}
+		for _, file := range files {
+			log.Printf("   📄 %s: %s", writer.Description(), file)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", format, err))
+			continue
+		}
+		log.Printf("✅ Formato %s salvo com sucesso!", format)
 	}
 
-	// Salvar relatório
-	if err := h.saveReport(report, reportFile); err != nil {
-		log.Printf("⚠️ Erro ao salvar relatório: %v", err)
-	} else {
-		log.Printf("✅ Relatório salvo com sucesso!")
+	return errors.Join(errs...)
+}
+
+// NewTimestamp gera o identificador usado no nome da pasta de uma execução
+func NewTimestamp() string {
+	return time.Now().Format("20060102_150405")
+}
+
+// SaveSummary salva um arquivo de resumo na pasta da execução
+func SaveSummary(baseDir, timestamp, filename, content string) (string, error) {
+	outputDir := filepath.Join(baseDir, timestamp)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("erro ao criar diretório de saída: %v", err)
 	}
 
-	return nil
+	path := filepath.Join(outputDir, filename)
+	if err := writeFile(path, []byte(content)); err != nil {
+		return "", err
+	}
+	return path, nil
 }
 
 // createOutputDirectory cria a estrutura de diretórios necessária
@@ -86,22 +162,53 @@ func (h *Handler) createOutputDirectory() (string, error) {
 	return outputDir, nil
 }
 
-// saveJSON salva os dados em formato JSON
-func (h *Handler) saveJSON(data *extractor.RepositoryData, filename string) error {
-	return data.SaveToJSON(filename)
+// filePrefix gera o prefixo comum aos arquivos do repositório
+func (h *Handler) filePrefix() string {
+	return fmt.Sprintf("%s_%s", h.owner, h.repo)
 }
 
-// saveReport salva o relatório em formato texto
-func (h *Handler) saveReport(report, filename string) error {
-	return os.WriteFile(filename, []byte(report), 0644)
+// joinOutputPath monta o caminho de um arquivo de saída
+func joinOutputPath(dir, filename string) string {
+	return filepath.Join(dir, filename)
 }
 
-// getJSONFilename gera o nome do arquivo JSON
-func (h *Handler) getJSONFilename() string {
-	return fmt.Sprintf("%s_%s_data.json", h.owner, h.repo)
+// writeFile grava o conteúdo de um arquivo de saída
+func writeFile(path string, content []byte) error {
+	return os.WriteFile(path, content, 0644)
 }
 
-// getReportFilename gera o nome do arquivo de relatório
-func (h *Handler) getReportFilename() string {
-	return fmt.Sprintf("%s_%s_report.txt", h.owner, h.repo)
-}
\ No newline at end of file
+// WriteFileAtomic grava em um arquivo temporário no mesmo diretório e o
+// renomeia, para que leitores (como o node_exporter ou o daemon) nunca vejam
+// um arquivo parcialmente escrito
+func WriteFileAtomic(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	// O prefixo "." e o sufixo ".tmp" fazem o collector ignorar o temporário
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, path)
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// ndjsonRecord representa uma linha do arquivo NDJSON
+type ndjsonRecord struct {
+	Repository string      `json:"repository"`
+	Collection string      `json:"collection"`
+	Item       interface{} `json:"item"`
+}
+
+// ndjsonWriter grava um registro JSON por linha, identificando a coleção de
+// origem, para ingestão em ferramentas de log e data lakes
+type ndjsonWriter struct{}
+
+func (ndjsonWriter) Format() string      { return FormatNDJSON }
+func (ndjsonWriter) Description() string { return "NDJSON (um registro por linha)" }
+
+func (ndjsonWriter) Write(dir, prefix string, data *extractor.RepositoryData, analysis *utils.Analysis) ([]string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	repository := data.BasicInfo.FullName
+
+	emit := func(collection string, item interface{}) error {
+		return encoder.Encode(ndjsonRecord{Repository: repository, Collection: collection, Item: item})
+	}
+
+	records := []struct {
+		collection string
+		item       interface{}
+	}{
+		{"basic_info", data.BasicInfo},
+		{"statistics", data.Statistics},
+		{"settings", data.Settings},
+		{"analysis", analysis},
+	}
+	for _, record := range records {
+		if err := emit(record.collection, record.item); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range data.Contributors {
+		if err := emit("contributors", item); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range data.RecentIssues {
+		if err := emit("issues", item); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range data.RecentPRs {
+		if err := emit("pull_requests", item); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range data.Releases {
+		if err := emit("releases", item); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range data.RecentCommits {
+		if err := emit("commits", item); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range data.RecentEvents {
+		if err := emit("events", item); err != nil {
+			return nil, err
+		}
+	}
+	for _, lang := range analysis.Languages {
+		if err := emit("languages", lang); err != nil {
+			return nil, err
+		}
+	}
+
+	path := joinOutputPath(dir, prefix+".ndjson")
+	if err := writeFile(path, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
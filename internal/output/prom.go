@@ -0,0 +1,47 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/internal/metrics"
+	"github-octokit-poc/utils"
+)
+
+// promWriter grava as métricas do repositório no formato texto do Prometheus,
+// com o mesmo conjunto de métricas do serve-metrics
+type promWriter struct{}
+
+func (promWriter) Format() string      { return FormatProm }
+func (promWriter) Description() string { return "Métricas Prometheus (textfile collector)" }
+
+func (promWriter) Write(dir, prefix string, data *extractor.RepositoryData, analysis *utils.Analysis) ([]string, error) {
+	var buf bytes.Buffer
+	if err := metrics.WriteText(&buf, metrics.AnalysisFamilies(data, analysis)); err != nil {
+		return nil, err
+	}
+
+	path := joinOutputPath(dir, prefix+".prom")
+	if err := WriteFileAtomic(path, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+// publishTextfile copia o .prom gravado na pasta da execução para o diretório
+// do textfile collector, com nome estável por repositório
+func publishTextfile(collectorDir, prefix, source string) (string, error) {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(collectorDir, fmt.Sprintf("github_repo_%s.prom", prefix))
+	if err := WriteFileAtomic(path, content); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,229 @@
+package output
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// sbomToolName identifica a ferramenta nos documentos gerados
+const sbomToolName = "github-octokit-poc"
+
+// sbomPackages retorna as dependências extraídas (vazio sem SBOM e manifestos)
+func sbomPackages(data *extractor.RepositoryData) []*extractor.Dependency {
+	if data.Dependencies == nil {
+		return nil
+	}
+	return data.Dependencies.Dependencies
+}
+
+// sbomTimestamp usa o momento da extração para que o documento seja
+// reproduzível a partir dos mesmos dados
+func sbomTimestamp(data *extractor.RepositoryData) string {
+	if data.ExtractionMeta != nil && !data.ExtractionMeta.ExtractedAt.IsZero() {
+		return data.ExtractionMeta.ExtractedAt.UTC().Format(time.RFC3339)
+	}
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// sbomUUID gera um UUID determinístico (formato v5) a partir do repositório e
+// do momento da extração
+func sbomUUID(data *extractor.RepositoryData) string {
+	sum := sha1.Sum([]byte(data.BasicInfo.FullName + "@" + sbomTimestamp(data)))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// cycloneDXComponent é um componente do documento CycloneDX
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Scope      string              `json:"scope,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Licenses   []cycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// renderCycloneDX gera um BOM CycloneDX 1.5 em JSON. O repositório é o
+// componente principal e depende diretamente das dependências diretas.
+func renderCycloneDX(data *extractor.RepositoryData, _ *utils.Analysis) ([]byte, error) {
+	root := cycloneDXComponent{
+		Type:   "application",
+		BOMRef: "repository:" + data.BasicInfo.FullName,
+		Name:   data.BasicInfo.FullName,
+	}
+
+	components := []cycloneDXComponent{}
+	direct := []string{}
+	for i, dep := range sbomPackages(data) {
+		ref := dep.PURL
+		if ref == "" {
+			ref = fmt.Sprintf("dependency-%d", i+1)
+		}
+		component := cycloneDXComponent{
+			Type:    "library",
+			BOMRef:  ref,
+			Name:    dep.Name,
+			Version: dep.Version,
+			PURL:    dep.PURL,
+			Properties: []cycloneDXProperty{
+				{Name: sbomToolName + ":ecosystem", Value: dep.Ecosystem},
+				{Name: sbomToolName + ":relationship", Value: dep.Relationship},
+			},
+		}
+		switch dep.Scope {
+		case extractor.ScopeRuntime:
+			component.Scope = "required"
+		case extractor.ScopeDevelopment, extractor.ScopeOptional, extractor.ScopePeer:
+			component.Scope = "optional"
+			component.Properties = append(component.Properties, cycloneDXProperty{Name: sbomToolName + ":scope", Value: dep.Scope})
+		}
+		if dep.License != "" {
+			component.Licenses = []cycloneDXLicense{{Expression: dep.License}}
+		}
+		if dep.Manifest != "" {
+			component.Properties = append(component.Properties, cycloneDXProperty{Name: sbomToolName + ":manifest", Value: dep.Manifest})
+		}
+		components = append(components, component)
+		if dep.Relationship == extractor.DependencyDirect {
+			direct = append(direct, ref)
+		}
+	}
+
+	document := map[string]interface{}{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": "urn:uuid:" + sbomUUID(data),
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": sbomTimestamp(data),
+			"tools": map[string]interface{}{
+				"components": []map[string]string{{"type": "application", "name": sbomToolName}},
+			},
+			"component": root,
+		},
+		"components":   components,
+		"dependencies": []cycloneDXDependency{{Ref: root.BOMRef, DependsOn: direct}},
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// spdxPackage é um pacote do documento SPDX
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+	RelationshipType   string `json:"relationshipType"`
+	Comment            string `json:"comment,omitempty"`
+}
+
+// renderSPDX gera um documento SPDX 2.3 em JSON. Todas as dependências são
+// relacionadas ao repositório por DEPENDS_ON; a relação (direta, indireta)
+// fica no comentário, pois o SBOM do GitHub não informa a cadeia transitiva.
+func renderSPDX(data *extractor.RepositoryData, _ *utils.Analysis) ([]byte, error) {
+	const rootID = "SPDXRef-Repository"
+	downloadLocation := "NOASSERTION"
+	if data.BasicInfo.CloneURL != "" {
+		downloadLocation = "git+" + data.BasicInfo.CloneURL
+	}
+
+	packages := []spdxPackage{{
+		SPDXID:           rootID,
+		Name:             data.BasicInfo.FullName,
+		DownloadLocation: downloadLocation,
+		LicenseConcluded: "NOASSERTION",
+		LicenseDeclared:  "NOASSERTION",
+	}}
+	relationships := []spdxRelationship{{
+		SPDXElementID:      "SPDXRef-DOCUMENT",
+		RelatedSPDXElement: rootID,
+		RelationshipType:   "DESCRIBES",
+	}}
+
+	for i, dep := range sbomPackages(data) {
+		pkg := spdxPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			Name:             dep.Name,
+			VersionInfo:      dep.Version,
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  "NOASSERTION",
+		}
+		if dep.License != "" {
+			pkg.LicenseConcluded = dep.License
+		}
+		if dep.PURL != "" {
+			pkg.ExternalRefs = []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  dep.PURL,
+			}}
+		}
+		packages = append(packages, pkg)
+
+		relationship := spdxRelationship{
+			SPDXElementID:      rootID,
+			RelatedSPDXElement: pkg.SPDXID,
+			RelationshipType:   "DEPENDS_ON",
+			Comment:            dep.Relationship,
+		}
+		if dep.Scope == extractor.ScopeDevelopment {
+			relationship.RelationshipType = "DEV_DEPENDENCY_OF"
+			relationship.SPDXElementID, relationship.RelatedSPDXElement = pkg.SPDXID, rootID
+		}
+		relationships = append(relationships, relationship)
+	}
+
+	document := map[string]interface{}{
+		"spdxVersion":       "SPDX-2.3",
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"name":              data.BasicInfo.FullName,
+		"documentNamespace": fmt.Sprintf("https://spdx.org/spdxdocs/%s/%s-%s", sbomToolName, data.BasicInfo.FullName, sbomUUID(data)),
+		"creationInfo": map[string]interface{}{
+			"created":  sbomTimestamp(data),
+			"creators": []string{"Tool: " + sbomToolName},
+		},
+		"documentDescribes": []string{rootID},
+		"packages":          packages,
+		"relationships":     relationships,
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
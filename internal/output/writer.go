@@ -0,0 +1,101 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// Writer grava um formato de saída a partir dos dados extraídos e dos
+// resultados dos analisadores
+type Writer interface {
+	// Format retorna o identificador usado em --format e OUTPUT_FORMATS
+	Format() string
+	// Description descreve o formato para ajuda e logs
+	Description() string
+	// Write grava os arquivos em dir, com nomes iniciados por prefix, e
+	// retorna os caminhos efetivamente gravados
+	Write(dir, prefix string, data *extractor.RepositoryData, analysis *utils.Analysis) ([]string, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Writer)
+	// registryOrder preserva a ordem de registro para gravações previsíveis
+	registryOrder []string
+)
+
+// Register adiciona um writer ao registro de formatos. Registrar o mesmo
+// formato duas vezes é um erro de programação e causa panic.
+func Register(w Writer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	format := strings.ToLower(w.Format())
+	if _, exists := registry[format]; exists {
+		panic(fmt.Sprintf("output: formato %q registrado duas vezes", format))
+	}
+	registry[format] = w
+	registryOrder = append(registryOrder, format)
+}
+
+// Lookup retorna o writer registrado para um formato
+func Lookup(format string) (Writer, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	w, ok := registry[strings.ToLower(format)]
+	return w, ok
+}
+
+// Formats lista os formatos registrados na ordem de registro
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	formats := make([]string, len(registryOrder))
+	copy(formats, registryOrder)
+	return formats
+}
+
+// sortByRegistry ordena formatos conforme a ordem de registro
+func sortByRegistry(formats []string) {
+	registryMu.RLock()
+	position := make(map[string]int, len(registryOrder))
+	for i, format := range registryOrder {
+		position[format] = i
+	}
+	registryMu.RUnlock()
+
+	sort.SliceStable(formats, func(i, j int) bool {
+		return position[formats[i]] < position[formats[j]]
+	})
+}
+
+// fileWriter implementa Writer para formatos que geram um único arquivo
+type fileWriter struct {
+	format      string
+	description string
+	suffix      string
+	render      func(data *extractor.RepositoryData, analysis *utils.Analysis) ([]byte, error)
+}
+
+func (w *fileWriter) Format() string      { return w.format }
+func (w *fileWriter) Description() string { return w.description }
+
+func (w *fileWriter) Write(dir, prefix string, data *extractor.RepositoryData, analysis *utils.Analysis) ([]string, error) {
+	content, err := w.render(data, analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	path := joinOutputPath(dir, prefix+w.suffix)
+	if err := writeFile(path, content); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"encoding/json"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/utils"
+)
+
+// Formatos de saída embutidos
+const (
+	FormatJSON      = "json"
+	FormatText      = "txt"
+	FormatMarkdown  = "md"
+	FormatHTML      = "html"
+	FormatCSV       = "csv"
+	FormatNDJSON    = "ndjson"
+	FormatProm      = "prom"
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+)
+
+// DefaultFormats lista os formatos gerados quando nenhum é informado
+var DefaultFormats = []string{FormatJSON, FormatText, FormatMarkdown}
+
+func init() {
+	Register(&fileWriter{
+		format:      FormatJSON,
+		description: "Dados completos",
+		suffix:      "_data.json",
+		render: func(data *extractor.RepositoryData, _ *utils.Analysis) ([]byte, error) {
+			return json.MarshalIndent(data, "", "  ")
+		},
+	})
+
+	Register(&fileWriter{
+		format:      FormatText,
+		description: "Relatório",
+		suffix:      "_report.txt",
+		render: func(data *extractor.RepositoryData, analysis *utils.Analysis) ([]byte, error) {
+			return []byte(utils.GenerateTextReport(data, analysis)), nil
+		},
+	})
+
+	Register(&fileWriter{
+		format:      FormatMarkdown,
+		description: "Relatório Markdown",
+		suffix:      "_report.md",
+		render: func(data *extractor.RepositoryData, analysis *utils.Analysis) ([]byte, error) {
+			return []byte(utils.GenerateMarkdownReport(data, analysis)), nil
+		},
+	})
+
+	Register(&fileWriter{
+		format:      FormatHTML,
+		description: "Dashboard HTML",
+		suffix:      "_report.html",
+		render: func(data *extractor.RepositoryData, analysis *utils.Analysis) ([]byte, error) {
+			return []byte(utils.GenerateHTMLReport(data, analysis)), nil
+		},
+	})
+
+	Register(&fileWriter{
+		format:      FormatCycloneDX,
+		description: "SBOM CycloneDX",
+		suffix:      "_sbom.cdx.json",
+		render:      renderCycloneDX,
+	})
+
+	Register(&fileWriter{
+		format:      FormatSPDX,
+		description: "SBOM SPDX",
+		suffix:      "_sbom.spdx.json",
+		render:      renderSPDX,
+	})
+
+	Register(csvWriter{})
+	Register(ndjsonWriter{})
+	Register(promWriter{})
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	ghclient "github-octokit-poc/github"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Budget controla o consumo do rate limit (core) compartilhado por todos os
+// jobs, preservando uma reserva para outros usos do mesmo token. O custo de
+// cada extração é medido pela diferença do rate limit restante antes e depois
+// dela, e a medição da última execução do repositório é usada como estimativa
+// da próxima.
+type Budget struct {
+	client  *ghclient.Client
+	reserve int
+
+	mu    sync.Mutex
+	costs map[string]int
+}
+
+// NewBudget cria um orçamento que mantém pelo menos reserve requisições livres
+func NewBudget(client *ghclient.Client, reserve int) *Budget {
+	return &Budget{client: client, reserve: reserve, costs: make(map[string]int)}
+}
+
+// Spend aguarda o orçamento estimado para key, executa fn e retorna quantas
+// requisições ela consumiu (zero quando não foi possível medir, por exemplo
+// na virada da janela do rate limit)
+func (b *Budget) Spend(ctx context.Context, key string, fn func() error) (int, error) {
+	before, err := b.wait(ctx, b.estimate(key))
+	if err != nil {
+		return 0, err
+	}
+
+	err = fn()
+	return b.measure(ctx, key, before), err
+}
+
+// estimate retorna o custo medido na última execução de key; repositórios
+// ainda não medidos usam o maior custo conhecido
+func (b *Budget) estimate(key string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cost, ok := b.costs[key]; ok {
+		return cost
+	}
+	highest := 0
+	for _, cost := range b.costs {
+		if cost > highest {
+			highest = cost
+		}
+	}
+	return highest
+}
+
+// measure registra o consumo de key a partir do rate limit restante antes e
+// depois da execução. Requisições de outros usos do mesmo token na mesma
+// janela também entram na conta, o que só torna a estimativa conservadora.
+func (b *Budget) measure(ctx context.Context, key string, before *github.Rate) int {
+	after, err := b.core(ctx)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível medir o consumo de %s: %v", key, err)
+		return 0
+	}
+	if before == nil || after == nil || !after.Reset.Time.Equal(before.Reset.Time) {
+		return 0
+	}
+
+	cost := before.Remaining - after.Remaining
+	if cost <= 0 {
+		return 0
+	}
+	b.mu.Lock()
+	b.costs[key] = cost
+	b.mu.Unlock()
+	return cost
+}
+
+// wait bloqueia até que cost requisições possam ser feitas sem invadir a
+// reserva, aguardando o reset da janela do rate limit quando necessário, e
+// retorna o rate limit no momento da liberação
+func (b *Budget) wait(ctx context.Context, cost int) (*github.Rate, error) {
+	for {
+		core, err := b.core(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if core == nil || core.Remaining-cost >= b.reserve {
+			return core, nil
+		}
+
+		wait := time.Until(core.Reset.Time) + time.Second
+		if wait < time.Second {
+			wait = time.Second
+		}
+		log.Printf("⏳ Orçamento de rate limit atingido (%d restantes, custo estimado de %d, reserva de %d); aguardando até %s",
+			core.Remaining, cost, b.reserve, core.Reset.Time.Format("15:04:05"))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// core consulta o rate limit core; a consulta ao /rate_limit não o consome
+func (b *Budget) core(ctx context.Context) (*github.Rate, error) {
+	limits, _, err := b.client.GitHub.RateLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar rate limit: %v", err)
+	}
+	return limits.GetCore(), nil
+}
@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule representa uma expressão cron de 5 campos
+// (minuto hora dia-do-mês mês dia-da-semana) ou um intervalo fixo (@every)
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, dow uint64
+	// domAny/dowAny indicam campos com "*": pela convenção do cron, se ambos
+	// os dias forem restritos, basta um deles coincidir
+	domAny, dowAny bool
+
+	every time.Duration
+}
+
+// cronField descreve os limites de um campo da expressão
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minuto", min: 0, max: 59}
+	hourField   = cronField{name: "hora", min: 0, max: 23}
+	domField    = cronField{name: "dia do mês", min: 1, max: 31}
+	monthField  = cronField{name: "mês", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Domingo aceita 0 e 7
+	dowField = cronField{name: "dia da semana", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors mapeia os atalhos aceitos para expressões equivalentes
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse interpreta uma expressão cron. Aceita "*", listas (1,15), intervalos
+// (1-5), passos (*/15, 0-30/10), nomes de meses e dias (jan, mon), os atalhos
+// @hourly, @daily, @weekly, @monthly e @yearly, e "@every 30m"
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || every < time.Minute {
+			return nil, fmt.Errorf("intervalo inválido em %q (mínimo 1m)", expr)
+		}
+		return &Schedule{expr: expr, every: every}, nil
+	}
+
+	fieldsExpr := expr
+	if descriptor, ok := descriptors[strings.ToLower(expr)]; ok {
+		fieldsExpr = descriptor
+	}
+
+	fields := strings.Fields(fieldsExpr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expressão cron %q deve ter 5 campos (minuto hora dia mês dia-da-semana)", expr)
+	}
+
+	schedule := &Schedule{expr: expr}
+	var err error
+	if schedule.minute, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+
+	// 7 também representa domingo
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = fields[2] == "*"
+	schedule.dowAny = fields[4] == "*"
+
+	return schedule, nil
+}
+
+// String retorna a expressão original
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next retorna o próximo horário de execução estritamente posterior a t
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		if !has(s.month, int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.dayMatches(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !has(s.hour, next.Hour()) {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !has(s.minute, next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	// Expressões impossíveis (ex: 30 de fevereiro) nunca executam
+	return time.Time{}
+}
+
+// dayMatches aplica a regra do cron para dia do mês e dia da semana
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseField converte um campo da expressão em um conjunto de bits
+func parseField(value string, field cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(value, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("passo inválido no campo %s: %q", field.name, part)
+			}
+		}
+
+		start, end := field.min, field.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			from, to, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = field.value(from); err != nil {
+				return 0, err
+			}
+			if end, err = field.value(to); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("intervalo inválido no campo %s: %q", field.name, part)
+			}
+		default:
+			var err error
+			if start, err = field.value(rangeExpr); err != nil {
+				return 0, err
+			}
+			// "5/15" significa de 5 até o fim, de 15 em 15
+			if !hasStep {
+				end = start
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// value converte um número ou nome validando os limites do campo
+func (f cronField) value(raw string) (int, error) {
+	if n, ok := f.names[strings.ToLower(raw)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("valor inválido no campo %s: %q (permitido %d-%d)", f.name, raw, f.min, f.max)
+	}
+	return n, nil
+}
+
+// has verifica se o valor está no conjunto
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,228 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github-octokit-poc/extractor"
+	ghclient "github-octokit-poc/github"
+	"github-octokit-poc/internal/cache"
+	"github-octokit-poc/internal/cli"
+	"github-octokit-poc/internal/output"
+	"github-octokit-poc/internal/snapshot"
+)
+
+// JobStatus guarda o estado das execuções de um repositório
+type JobStatus struct {
+	Repository   string    `json:"repository"`
+	Runs         int       `json:"runs"`
+	Failures     int       `json:"failures"`
+	LastRun      time.Time `json:"last_run"`
+	LastSuccess  time.Time `json:"last_success"`
+	LastDuration string    `json:"last_duration"`
+	LastRequests int       `json:"last_requests,omitempty"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastSnapshot string    `json:"last_snapshot,omitempty"`
+}
+
+// Status é o estado do daemon exposto no arquivo e no endpoint de status
+type Status struct {
+	StartedAt   time.Time    `json:"started_at"`
+	Schedule    string       `json:"schedule"`
+	Running     bool         `json:"running"`
+	LastRunAt   time.Time    `json:"last_run_at"`
+	NextRunAt   time.Time    `json:"next_run_at"`
+	SnapshotDir string       `json:"snapshot_dir"`
+	Jobs        []*JobStatus `json:"jobs"`
+}
+
+// ExtractHook é executado após cada extração bem-sucedida (ex: alertas)
+type ExtractHook func(data *extractor.RepositoryData)
+
+// Scheduler executa a extração dos repositórios conforme a expressão cron,
+// salvando um snapshot a cada execução. Os jobs rodam em sequência e
+// compartilham o mesmo orçamento de rate limit.
+type Scheduler struct {
+	client     *ghclient.Client
+	schedule   *Schedule
+	budget     *Budget
+	store      *snapshot.Store
+	targets    []cli.Target
+	statusFile string
+	hooks      []ExtractHook
+
+	mu     sync.RWMutex
+	status *Status
+	jobs   map[string]*JobStatus
+}
+
+// New cria o scheduler; repositórios repetidos são considerados apenas uma
+// vez e statusFile vazio desativa o arquivo de status
+func New(client *ghclient.Client, schedule *Schedule, budget *Budget, store *snapshot.Store, targets []cli.Target, statusFile string) *Scheduler {
+	s := &Scheduler{
+		client:     client,
+		schedule:   schedule,
+		budget:     budget,
+		store:      store,
+		statusFile: statusFile,
+		status: &Status{
+			StartedAt:   time.Now(),
+			Schedule:    schedule.String(),
+			SnapshotDir: store.Dir(),
+		},
+		jobs: make(map[string]*JobStatus),
+	}
+
+	for _, target := range targets {
+		key := cache.Key(target.Owner, target.Repo)
+		if _, exists := s.jobs[key]; exists {
+			continue
+		}
+		job := &JobStatus{Repository: target.FullName()}
+		s.jobs[key] = job
+		s.status.Jobs = append(s.status.Jobs, job)
+		s.targets = append(s.targets, target)
+	}
+
+	return s
+}
+
+// AfterExtract registra uma função executada após cada extração bem-sucedida
+func (s *Scheduler) AfterExtract(hook ExtractHook) {
+	s.hooks = append(s.hooks, hook)
+}
+
+// Run aguarda cada horário da expressão e executa todos os jobs, até o
+// contexto ser cancelado. Execuções atrasadas não se acumulam: o próximo
+// horário é calculado ao fim de cada rodada.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		next := s.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("⚠️ A expressão %q nunca será executada", s.schedule)
+			return
+		}
+
+		s.mu.Lock()
+		s.status.NextRunAt = next
+		s.mu.Unlock()
+		s.writeStatusFile()
+		log.Printf("⏰ Próxima execução em %s", next.Format("02/01/2006 15:04"))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.RunOnce(ctx)
+	}
+}
+
+// RunOnce executa imediatamente a extração de todos os repositórios
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	s.mu.Lock()
+	s.status.Running = true
+	s.status.LastRunAt = time.Now()
+	s.mu.Unlock()
+
+	log.Printf("🔄 Iniciando rodada agendada (%d repositórios)", len(s.targets))
+	for _, target := range s.targets {
+		if ctx.Err() != nil {
+			break
+		}
+		s.runJob(ctx, target)
+	}
+
+	s.mu.Lock()
+	s.status.Running = false
+	s.mu.Unlock()
+	s.writeStatusFile()
+}
+
+// runJob extrai um repositório, respeitando o orçamento de rate limit, e
+// salva o snapshot
+func (s *Scheduler) runJob(ctx context.Context, target cli.Target) {
+	start := time.Now()
+
+	var path string
+	var data *extractor.RepositoryData
+	key := cache.Key(target.Owner, target.Repo)
+	requests, err := s.budget.Spend(ctx, key, func() error {
+		var err error
+		data, err = extractor.ExtractRepositoryData(s.client, target.Owner, target.Repo)
+		return err
+	})
+	if err == nil {
+		path, err = s.store.Save(data)
+	}
+
+	s.mu.Lock()
+	job := s.jobs[key]
+	job.Runs++
+	job.LastRun = start
+	job.LastDuration = time.Since(start).Round(time.Millisecond).String()
+	if requests > 0 {
+		job.LastRequests = requests
+	}
+	if err != nil {
+		job.Failures++
+		job.LastError = err.Error()
+		log.Printf("❌ %s: %v", target.FullName(), err)
+	} else {
+		job.LastSuccess = start
+		job.LastError = ""
+		job.LastSnapshot = path
+		log.Printf("📸 Snapshot de %s salvo em %s (%d requisições)", target.FullName(), path, requests)
+	}
+	s.mu.Unlock()
+
+	if data != nil {
+		for _, hook := range s.hooks {
+			hook(data)
+		}
+	}
+
+	s.writeStatusFile()
+}
+
+// statusJSON serializa o estado atual
+func (s *Scheduler) statusJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.MarshalIndent(s.status, "", "  ")
+}
+
+// ServeHTTP expõe o estado atual em JSON
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	content, err := s.statusJSON()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(append(content, '\n'))
+}
+
+// writeStatusFile grava o estado atual no arquivo de status, se configurado
+func (s *Scheduler) writeStatusFile() {
+	if s.statusFile == "" {
+		return
+	}
+
+	content, err := s.statusJSON()
+	if err == nil {
+		err = output.WriteFileAtomic(s.statusFile, append(content, '\n'))
+	}
+	if err != nil {
+		log.Printf("⚠️ Erro ao gravar arquivo de status: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Allowlist define os repositórios que o servidor aceita extrair: entradas
+// "owner/repo", "owner/*" (todos os repositórios do dono) ou "*" (qualquer
+// repositório, apenas por opção explícita)
+type Allowlist struct {
+	all    bool
+	owners map[string]bool
+	repos  map[string]bool
+}
+
+// ParseAllowlist interpreta as entradas da allowlist; nomes não diferenciam
+// maiúsculas, como no GitHub
+func ParseAllowlist(entries []string) (*Allowlist, error) {
+	allowlist := &Allowlist{owners: make(map[string]bool), repos: make(map[string]bool)}
+	for _, raw := range entries {
+		value := strings.ToLower(strings.TrimSpace(raw))
+		if value == "" {
+			continue
+		}
+		if value == "*" {
+			allowlist.all = true
+			continue
+		}
+
+		owner, repo, found := strings.Cut(value, "/")
+		if !found || owner == "" || repo == "" || owner == "*" || strings.Contains(repo, "/") {
+			return nil, fmt.Errorf("entrada inválida na allowlist: %q (use owner/repo, owner/* ou *)", raw)
+		}
+		if repo == "*" {
+			allowlist.owners[owner] = true
+		} else {
+			allowlist.repos[owner+"/"+repo] = true
+		}
+	}
+	return allowlist, nil
+}
+
+// Empty indica que nenhum repositório é aceito
+func (a *Allowlist) Empty() bool {
+	return !a.all && len(a.owners) == 0 && len(a.repos) == 0
+}
+
+// Allows verifica se o repositório pode ser extraído
+func (a *Allowlist) Allows(owner, repo string) bool {
+	owner, repo = strings.ToLower(owner), strings.ToLower(repo)
+	return a.all || a.owners[owner] || a.repos[owner+"/"+repo]
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"sync"
+
+	"github-octokit-poc/extractor"
+)
+
+// flightCall representa uma extração em andamento
+type flightCall struct {
+	wg   sync.WaitGroup
+	data *extractor.RepositoryData
+	err  error
+}
+
+// flightGroup agrupa chamadas concorrentes para a mesma chave, de forma que
+// apenas uma extração por repositório chegue à API do GitHub
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// newFlightGroup cria um grupo de coalescência vazio
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// Do executa fn uma única vez por chave enquanto houver chamadas em andamento;
+// quem chega depois espera e recebe o mesmo resultado (shared = true)
+func (g *flightGroup) Do(key string, fn func() (*extractor.RepositoryData, error)) (data *extractor.RepositoryData, err error, shared bool) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.data, call.err, true
+	}
+
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	call.data, call.err = fn()
+	return call.data, call.err, false
+}
+
+// repoLock é o mutex de um repositório, com a contagem de quem o aguarda
+type repoLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// repoLocks serializa, por repositório, as extrações completas e as
+// atualizações incrementais dos webhooks, para que uma não sobrescreva a outra
+type repoLocks struct {
+	mu    sync.Mutex
+	locks map[string]*repoLock
+}
+
+// newRepoLocks cria o conjunto de locks vazio
+func newRepoLocks() *repoLocks {
+	return &repoLocks{locks: make(map[string]*repoLock)}
+}
+
+// lock bloqueia a chave e retorna a função que a libera; o mutex é descartado
+// quando ninguém mais o usa
+func (l *repoLocks) lock(key string) func() {
+	l.mu.Lock()
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &repoLock{}
+		l.locks[key] = entry
+	}
+	entry.refs++
+	l.mu.Unlock()
+
+	entry.mu.Lock()
+	return func() {
+		entry.mu.Unlock()
+
+		l.mu.Lock()
+		if entry.refs--; entry.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+	ghclient "github-octokit-poc/github"
+	"github-octokit-poc/internal/cache"
+	"github-octokit-poc/utils"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// reportRenderer descreve um formato de relatório servido em /report
+type reportRenderer struct {
+	contentType string
+	render      func(data *extractor.RepositoryData, analysis *utils.Analysis) string
+}
+
+// reportRenderers lista os formatos aceitos em ?format=
+var reportRenderers = map[string]reportRenderer{
+	"txt":  {"text/plain; charset=utf-8", utils.GenerateTextReport},
+	"md":   {"text/markdown; charset=utf-8", utils.GenerateMarkdownReport},
+	"html": {"text/html; charset=utf-8", utils.GenerateHTMLReport},
+}
+
+// defaultReportFormat é usado quando ?format= não é informado
+const defaultReportFormat = "md"
+
+// refreshCooldown é a idade mínima da extração em cache para que ?refresh=true
+// faça uma nova extração, limitando o consumo de rate limit por requisição
+const refreshCooldown = time.Minute
+
+// AnalysisResponse é o corpo de GET /repos/{owner}/{repo}/analysis
+type AnalysisResponse struct {
+	Repository *extractor.RepositoryData `json:"repository"`
+	Analysis   *utils.Analysis           `json:"analysis"`
+	Cached     bool                      `json:"cached"`
+}
+
+// RepositoryHealthResponse é o corpo de GET /repos/{owner}/{repo}/health
+type RepositoryHealthResponse struct {
+	Repository string                  `json:"repository"`
+	Health     *utils.RepositoryHealth `json:"health"`
+	Cached     bool                    `json:"cached"`
+}
+
+// HealthResponse é o corpo de GET /health
+type HealthResponse struct {
+	Status             string  `json:"status"`
+	UptimeSeconds      float64 `json:"uptime_seconds"`
+	CachedRepositories int     `json:"cached_repositories"`
+}
+
+// errorResponse é o corpo JSON das respostas de erro
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server expõe a análise de repositórios via HTTP, com cache em memória e
+// coalescência de requisições simultâneas para o mesmo repositório
+type Server struct {
+	client    *ghclient.Client
+	cache     *cache.Cache
+	flights   *flightGroup
+	allowlist *Allowlist
+	analyzer  utils.Analyzer
+	started   time.Time
+	mux       *http.ServeMux
+
+	// locks serializa extrações e webhooks do mesmo repositório
+	locks *repoLocks
+
+	webhookSecret []byte
+}
+
+// New cria o servidor; ttl define por quanto tempo uma extração é reutilizada
+// (zero mantém os dados até o processo terminar), cacheSize limita os
+// repositórios em cache (zero: sem limite) e apenas os repositórios da
+// allowlist são extraídos; analyzer define as opções das análises servidas
+func New(client *ghclient.Client, ttl time.Duration, cacheSize int, allowlist *Allowlist, analyzer utils.Analyzer) *Server {
+	s := &Server{
+		client:    client,
+		cache:     cache.NewWithLimit(ttl, cacheSize),
+		flights:   newFlightGroup(),
+		locks:     newRepoLocks(),
+		allowlist: allowlist,
+		analyzer:  analyzer,
+		started:   time.Now(),
+		mux:       http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/health", s.handleHealth)
+	s.mux.HandleFunc("/repos/", s.handleRepository)
+
+	return s
+}
+
+// ServeHTTP encaminha a requisição para as rotas registradas
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleHealth responde GET /health
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &HealthResponse{
+		Status:             "ok",
+		UptimeSeconds:      time.Since(s.started).Seconds(),
+		CachedRepositories: s.cache.Len(),
+	})
+}
+
+// handleRepository responde GET /repos/{owner}/{repo}/analysis,
+// GET /repos/{owner}/{repo}/report?format=md e GET /repos/{owner}/{repo}/health
+func (s *Server) handleRepository(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/repos/"), "/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		writeError(w, http.StatusNotFound, "rota não encontrada; use /repos/{owner}/{repo}/analysis, /repos/{owner}/{repo}/report ou /repos/{owner}/{repo}/health")
+		return
+	}
+	owner, repo, action := parts[0], parts[1], parts[2]
+
+	// Valida o formato antes de gastar chamadas à API
+	var renderer reportRenderer
+	switch action {
+	case "analysis", "health":
+	case "report":
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = defaultReportFormat
+		}
+		var ok bool
+		if renderer, ok = reportRenderers[format]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("formato %q não suportado (disponíveis: %s)",
+				format, strings.Join(reportFormats(), ", ")))
+			return
+		}
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("recurso %q não encontrado", action))
+		return
+	}
+
+	// Só os repositórios da allowlist podem consumir o rate limit do token
+	if !s.allowlist.Allows(owner, repo) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("repositório %s/%s fora da allowlist do servidor", owner, repo))
+		return
+	}
+
+	data, cached, err := s.repositoryData(owner, repo, r.URL.Query().Get("refresh") == "true")
+	if err != nil {
+		writeError(w, statusForError(err), err.Error())
+		return
+	}
+	data, analysis := redactSecurity(data, s.analyzer.Analyze(data))
+
+	switch action {
+	case "analysis":
+		writeJSON(w, http.StatusOK, &AnalysisResponse{Repository: data, Analysis: analysis, Cached: cached})
+		return
+	case "health":
+		writeJSON(w, http.StatusOK, &RepositoryHealthResponse{
+			Repository: data.BasicInfo.FullName,
+			Health:     analysis.Health,
+			Cached:     cached,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", renderer.contentType)
+	w.Write([]byte(renderer.render(data, analysis)))
+}
+
+// repositoryData retorna os dados do cache ou extrai o repositório; extrações
+// simultâneas do mesmo repositório são feitas uma única vez. O refresh só é
+// atendido quando a extração em cache tem mais de refreshCooldown.
+func (s *Server) repositoryData(owner, repo string, refresh bool) (*extractor.RepositoryData, bool, error) {
+	if data, ok := s.cache.Get(owner, repo); ok {
+		if !refresh {
+			return data, true, nil
+		}
+		if age := time.Since(data.ExtractionMeta.ExtractedAt); age < refreshCooldown {
+			log.Printf("⏳ Refresh de %s/%s ignorado: extração feita há %s (mínimo %s)",
+				owner, repo, age.Round(time.Second), refreshCooldown)
+			return data, true, nil
+		}
+	}
+
+	key := cache.Key(owner, repo)
+	data, err, shared := s.flights.Do(key, func() (*extractor.RepositoryData, error) {
+		// Webhooks recebidos durante a extração esperam o resultado entrar no
+		// cache e são aplicados sobre ele
+		unlock := s.locks.lock(key)
+		defer unlock()
+
+		data, err := extractor.ExtractRepositoryData(s.client, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.Set(owner, repo, data)
+		return data, nil
+	})
+	if shared {
+		log.Printf("♻️ Requisição para %s/%s aproveitou uma extração em andamento", owner, repo)
+	}
+	return data, false, err
+}
+
+// redactSecurity remove os detalhes dos alertas de segurança (títulos, URLs,
+// pacotes e arquivos) dos dados servidos, mantendo a situação dos recursos,
+// as contagens e o score. A API não tem autenticação e os alertas de
+// repositórios privados não podem ficar expostos a quem alcança a porta.
+// Os dados em cache não são alterados.
+func redactSecurity(data *extractor.RepositoryData, analysis *utils.Analysis) (*extractor.RepositoryData, *utils.Analysis) {
+	if data.Security == nil {
+		return data, analysis
+	}
+
+	redactedData := *data
+	redactedData.Security = &extractor.SecurityData{Features: data.Security.Features}
+
+	redactedAnalysis := *analysis
+	if analysis.Security != nil {
+		security := *analysis.Security
+		security.TopAlerts = nil
+		redactedAnalysis.Security = &security
+	}
+	return &redactedData, &redactedAnalysis
+}
+
+// statusForError converte erros da API do GitHub em status HTTP
+func statusForError(err error) int {
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		switch ghErr.Response.StatusCode {
+		case http.StatusNotFound:
+			return http.StatusNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return http.StatusBadGateway
+		}
+	}
+
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadGateway
+}
+
+// allowGet rejeita métodos diferentes de GET e HEAD
+func allowGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	writeError(w, http.StatusMethodNotAllowed, "método não permitido")
+	return false
+}
+
+// writeJSON serializa a resposta como JSON indentado
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	encoded, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(append(encoded, '\n'))
+}
+
+// writeError responde com {"error": "..."}
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &errorResponse{Error: message})
+}
+
+// reportFormats retorna os formatos de relatório em ordem alfabética
+func reportFormats() []string {
+	formats := make([]string, 0, len(reportRenderers))
+	for format := range reportRenderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/internal/cache"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// WebhookResponse é o corpo das respostas de POST /webhook
+type WebhookResponse struct {
+	Status     string `json:"status"`
+	Event      string `json:"event"`
+	Repository string `json:"repository,omitempty"`
+}
+
+// EnableWebhooks registra POST /webhook, que recebe entregas do GitHub
+// assinadas com o segredo compartilhado e atualiza incrementalmente os dados
+// em cache do repositório afetado
+func (s *Server) EnableWebhooks(secret string) {
+	s.webhookSecret = []byte(secret)
+	s.mux.HandleFunc("/webhook", s.handleWebhook)
+}
+
+// handleWebhook valida a assinatura X-Hub-Signature-256 e aplica o evento
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, "método não permitido")
+		return
+	}
+
+	// Apenas a assinatura SHA-256 é aceita
+	if r.Header.Get(github.SHA256SignatureHeader) == "" {
+		writeError(w, http.StatusUnauthorized, "cabeçalho "+github.SHA256SignatureHeader+" ausente")
+		return
+	}
+	payload, err := github.ValidatePayload(r, s.webhookSecret)
+	if err != nil {
+		log.Printf("⚠️ Webhook rejeitado (%s): %v", github.DeliveryID(r), err)
+		writeError(w, http.StatusUnauthorized, "assinatura inválida")
+		return
+	}
+
+	eventName := github.WebHookType(r)
+	event, err := github.ParseWebHook(eventName, payload)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := event.(*github.PingEvent); ok {
+		writeJSON(w, http.StatusOK, &WebhookResponse{Status: "pong", Event: eventName})
+		return
+	}
+
+	owner, repo := webhookRepository(event)
+	if owner == "" || repo == "" {
+		writeJSON(w, http.StatusAccepted, &WebhookResponse{Status: "ignored", Event: eventName})
+		return
+	}
+
+	response := &WebhookResponse{Status: s.applyWebhookEvent(owner, repo, event), Event: eventName, Repository: owner + "/" + repo}
+	log.Printf("🪝 Webhook %s de %s/%s: %s", eventName, owner, repo, response.Status)
+
+	status := http.StatusOK
+	if response.Status != "updated" {
+		status = http.StatusAccepted
+	}
+	writeJSON(w, status, response)
+}
+
+// applyWebhookEvent atualiza os dados em cache do repositório sem renovar a
+// validade, que continua contando da última extração completa; repositórios
+// fora do cache são ignorados, pois a próxima requisição fará a extração completa
+func (s *Server) applyWebhookEvent(owner, repo string, event interface{}) string {
+	unlock := s.locks.lock(cache.Key(owner, repo))
+	defer unlock()
+
+	data, ok := s.cache.Get(owner, repo)
+	if !ok {
+		return "not_cached"
+	}
+
+	updated, ok := extractor.ApplyWebhookEvent(data, event)
+	if !ok {
+		return "ignored"
+	}
+	if !s.cache.Update(owner, repo, updated) {
+		return "not_cached"
+	}
+	return "updated"
+}
+
+// webhookRepository identifica o repositório afetado pelo evento
+func webhookRepository(event interface{}) (owner, repo string) {
+	var fullName string
+	switch e := event.(type) {
+	case *github.PushEvent:
+		fullName = e.GetRepo().GetFullName()
+	case *github.IssuesEvent:
+		fullName = e.GetRepo().GetFullName()
+	case *github.PullRequestEvent:
+		fullName = e.GetRepo().GetFullName()
+	case *github.ReleaseEvent:
+		fullName = e.GetRepo().GetFullName()
+	}
+
+	owner, repo, _ = strings.Cut(fullName, "/")
+	return owner, repo
+}
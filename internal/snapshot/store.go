@@ -0,0 +1,146 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+	"github-octokit-poc/internal/output"
+)
+
+// timeLayout define o nome dos arquivos de snapshot (UTC, ordenável)
+const timeLayout = "20060102T150405Z"
+
+// Store persiste snapshots de RepositoryData em disco, um diretório por
+// repositório: <dir>/<owner>/<repo>/<timestamp>.json
+type Store struct {
+	dir string
+}
+
+// Entry identifica um snapshot salvo
+type Entry struct {
+	Path    string    `json:"path"`
+	TakenAt time.Time `json:"taken_at"`
+}
+
+// NewStore cria um store no diretório informado
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir retorna o diretório base do store
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save grava os dados extraídos como um novo snapshot e retorna o caminho
+func (s *Store) Save(data *extractor.RepositoryData) (string, error) {
+	meta := data.ExtractionMeta
+	takenAt := meta.ExtractedAt
+	if takenAt.IsZero() {
+		takenAt = time.Now()
+	}
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("erro ao serializar snapshot: %v", err)
+	}
+
+	path := filepath.Join(s.repoDir(meta.Owner, meta.Repo), takenAt.UTC().Format(timeLayout)+".json")
+	if err := output.WriteFileAtomic(path, content); err != nil {
+		return "", fmt.Errorf("erro ao salvar snapshot: %v", err)
+	}
+	return path, nil
+}
+
+// Empty indica que o store não tem nenhum snapshot salvo, de qualquer
+// repositório (inclusive quando o diretório ainda não existe)
+func (s *Store) Empty() bool {
+	empty := true
+	filepath.WalkDir(s.dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			empty = false
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return empty
+}
+
+// List retorna os snapshots de um repositório, do mais antigo para o mais recente
+func (s *Store) List(owner, repo string) ([]Entry, error) {
+	files, err := os.ReadDir(s.repoDir(owner, repo))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		takenAt, err := time.Parse(timeLayout, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue // Arquivos com outro nome não são snapshots
+		}
+		entries = append(entries, Entry{
+			Path:    filepath.Join(s.repoDir(owner, repo), name),
+			TakenAt: takenAt,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].TakenAt.Before(entries[j].TakenAt)
+	})
+	return entries, nil
+}
+
+// Load lê um snapshot salvo
+func (s *Store) Load(entry Entry) (*extractor.RepositoryData, error) {
+	content, err := os.ReadFile(entry.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var data extractor.RepositoryData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("snapshot inválido %s: %v", entry.Path, err)
+	}
+	return &data, nil
+}
+
+// Previous retorna o snapshot mais recente tirado antes do momento informado;
+// sem snapshots anteriores, retorna nil
+func (s *Store) Previous(owner, repo string, before time.Time) (*extractor.RepositoryData, error) {
+	entries, err := s.List(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os nomes têm precisão de segundos: o snapshot da própria extração não conta
+	limit := before.UTC().Truncate(time.Second)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].TakenAt.Before(limit) {
+			return s.Load(entries[i])
+		}
+	}
+	return nil, nil
+}
+
+// repoDir retorna o diretório dos snapshots de um repositório; nomes no
+// GitHub não diferenciam maiúsculas
+func (s *Store) repoDir(owner, repo string) string {
+	return filepath.Join(s.dir, strings.ToLower(owner), strings.ToLower(repo))
+}
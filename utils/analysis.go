@@ -0,0 +1,62 @@
+package utils
+
+import "github-octokit-poc/extractor"
+
+// Analysis agrupa os resultados de todos os analisadores de um repositório,
+// permitindo que relatórios e writers reutilizem o mesmo cálculo
+type Analysis struct {
+	Languages    []*LanguageStats    `json:"languages"`
+	Activity     *ActivityMetrics    `json:"activity"`
+	Contributors *ContributorStats   `json:"contributors"`
+	Health       *RepositoryHealth   `json:"health"`
+	BusFactor    *BusFactorAnalysis  `json:"bus_factor"`
+	Stats        *StatsAnalysis      `json:"stats,omitempty"`
+	Community    *CommunityStandards `json:"community,omitempty"`
+	CodeOwners   *CodeOwnersAnalysis `json:"codeowners,omitempty"`
+	Tree         *TreeComposition    `json:"tree,omitempty"`
+	Dependencies *DependencySummary  `json:"dependencies,omitempty"`
+	Security     *SecurityAnalysis   `json:"security,omitempty"`
+	Releases     *ReleaseAnalysis    `json:"releases,omitempty"`
+	Downloads    *DownloadAnalysis   `json:"downloads,omitempty"`
+	Commits      *CommitHygiene      `json:"commits,omitempty"`
+}
+
+// Analyzer executa os analisadores com as opções da execução, definidas pela
+// camada de comandos; o valor zero usa o modelo de saúde padrão
+type Analyzer struct {
+	HealthModel *HealthModel
+}
+
+// Analyze executa todos os analisadores com as opções padrão
+func Analyze(data *extractor.RepositoryData) *Analysis {
+	return Analyzer{}.Analyze(data)
+}
+
+// Analyze executa todos os analisadores sobre os dados extraídos, sem
+// snapshot base para as tendências
+func (a Analyzer) Analyze(data *extractor.RepositoryData) *Analysis {
+	return a.AnalyzeWithBaseline(data, nil)
+}
+
+// AnalyzeWithBaseline executa todos os analisadores; baseline é o snapshot
+// anterior usado na tendência de downloads, carregado pela camada de comandos
+func (a Analyzer) AnalyzeWithBaseline(data, baseline *extractor.RepositoryData) *Analysis {
+	analysis := &Analysis{
+		Languages:    AnalyzeLanguages(data),
+		Activity:     AnalyzeActivity(data),
+		Contributors: AnalyzeContributors(data),
+		BusFactor:    AnalyzeBusFactor(data),
+		Stats:        AnalyzeStats(data),
+		Community:    AnalyzeCommunity(data),
+		CodeOwners:   AnalyzeCodeOwners(data),
+		Tree:         AnalyzeTree(data),
+		Dependencies: AnalyzeDependencies(data),
+		Security:     AnalyzeSecurity(data),
+		Releases:     AnalyzeReleases(data),
+		Downloads:    AnalyzeDownloads(data, baseline),
+		Commits:      AnalyzeCommitHygiene(data),
+	}
+	// A saúde vem por último: os sinais do modelo leem as demais análises
+	analysis.Health = analyzeHealth(data, analysis, a.HealthModel)
+	return analysis
+}
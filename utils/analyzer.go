@@ -43,7 +43,10 @@ type RepositoryHealth struct {
 	LastReleaseDays    int     `json:"last_release_days_ago"`
 	OpenIssuesRatio    float64 `json:"open_issues_ratio"`
 	StaleIssues        int     `json:"stale_issues_count"`
+	OpenIssuesRatioBasis *SignalBasis `json:"open_issues_ratio_basis"`
+	StaleIssuesBasis     *SignalBasis `json:"stale_issues_basis"`
 	MaintenanceStatus  string  `json:"maintenance_status"`
+	Contributions      []*HealthContribution `json:"contributions"`
 }
 
 // AnalyzeLanguages analisa a distribuição de linguagens
@@ -149,10 +152,6 @@ func AnalyzeContributors(data *extractor.RepositoryData) *ContributorStats {
 		TotalContributors: len(data.Contributors),
 	}
 
-	if len(data.Contributors) == 0 {
-		return stats
-	}
-
 	// Top 10 colaboradores
 	topCount := 10
 	if len(data.Contributors) < topCount {
@@ -170,8 +169,18 @@ func AnalyzeContributors(data *extractor.RepositoryData) *ContributorStats {
 	return stats
 }
 
-// AnalyzeHealth analisa a saúde do repositório
-func AnalyzeHealth(data *extractor.RepositoryData) *RepositoryHealth {
+// AnalyzeHealth analisa a saúde do repositório com o modelo informado (nil
+// usa DefaultHealthModel). Os sinais leem os resultados dos demais
+// analisadores, então todos são executados; com a análise completa em mãos,
+// use Analysis.Health.
+func AnalyzeHealth(data *extractor.RepositoryData, model *HealthModel) *RepositoryHealth {
+	return Analyzer{HealthModel: model}.Analyze(data).Health
+}
+
+// analyzeHealth calcula os indicadores de saúde e aplica o modelo sobre a
+// análise já calculada; os indicadores base ficam em analysis.Health antes do
+// score, para os sinais que os usam
+func analyzeHealth(data *extractor.RepositoryData, analysis *Analysis, model *HealthModel) *RepositoryHealth {
 	now := time.Now()
 	health := &RepositoryHealth{}
 
@@ -187,9 +196,16 @@ func AnalyzeHealth(data *extractor.RepositoryData) *RepositoryHealth {
 		health.LastReleaseDays = int(now.Sub(lastRelease).Hours() / 24)
 	}
 
-	// Ratio de issues abertas
-	if data.Statistics.Issues > 0 {
-		// Estimativa baseada nas issues da amostra
+	// Ratio de issues abertas e issues obsoletas: contagens totais da Search API
+	// quando disponíveis, senão a amostra de issues atualizadas recentemente
+	if counts := data.IssueCounts; counts != nil {
+		if counts.Total() > 0 {
+			health.OpenIssuesRatio = float64(counts.Open) / float64(counts.Total())
+		}
+		health.StaleIssues = counts.StaleOpen
+		health.OpenIssuesRatioBasis = searchBasis(counts)
+		health.StaleIssuesBasis = searchBasis(counts)
+	} else {
 		openCount := 0
 		for _, issue := range data.RecentIssues {
 			if issue.State == "open" {
@@ -199,70 +215,35 @@ func AnalyzeHealth(data *extractor.RepositoryData) *RepositoryHealth {
 		if len(data.RecentIssues) > 0 {
 			health.OpenIssuesRatio = float64(openCount) / float64(len(data.RecentIssues))
 		}
-	}
 
-	// Issues obsoletas (mais de 90 dias sem atividade)
-	staleThreshold := now.AddDate(0, 0, -90)
-	for _, issue := range data.RecentIssues {
-		if issue.State == "open" && issue.UpdatedAt.Before(staleThreshold) {
-			health.StaleIssues++
+		// Issues obsoletas (sem atividade há StaleIssueDays dias)
+		staleThreshold := now.AddDate(0, 0, -extractor.StaleIssueDays)
+		for _, issue := range data.RecentIssues {
+			if issue.State == "open" && issue.UpdatedAt.Before(staleThreshold) {
+				health.StaleIssues++
+			}
 		}
+		health.OpenIssuesRatioBasis = withFallback(sampleProportionBasis(len(data.RecentIssues)), data.IssueCountsError)
+		health.StaleIssuesBasis = withFallback(sampleCountBasis(len(data.RecentIssues)), data.IssueCountsError)
 	}
 
-	// Calcular score de saúde (0-100)
-	score := 100.0
-
-	// Penalizar por inatividade
-	if health.LastCommitDays > 30 {
-		score -= 20
-	} else if health.LastCommitDays > 7 {
-		score -= 10
-	}
-
-	// Penalizar por releases antigas
-	if health.LastReleaseDays > 365 {
-		score -= 15
-	} else if health.LastReleaseDays > 180 {
-		score -= 10
-	}
-
-	// Penalizar por muitas issues abertas
-	if health.OpenIssuesRatio > 0.8 {
-		score -= 15
-	} else if health.OpenIssuesRatio > 0.6 {
-		score -= 10
-	}
-
-	// Penalizar por issues obsoletas
-	if health.StaleIssues > 10 {
-		score -= 10
-	} else if health.StaleIssues > 5 {
-		score -= 5
-	}
-
-	health.HealthScore = score
-
-	// Determinar status de manutenção
-	switch {
-	case score >= 90:
-		health.MaintenanceStatus = "Excelente"
-	case score >= 80:
-		health.MaintenanceStatus = "Muito Bom"
-	case score >= 70:
-		health.MaintenanceStatus = "Bom"
-	case score >= 60:
-		health.MaintenanceStatus = "Regular"
-	case score >= 50:
-		health.MaintenanceStatus = "Precisa Atenção"
-	default:
-		health.MaintenanceStatus = "Crítico"
+	// Calcular score de saúde (0-100) conforme o modelo configurado
+	if model == nil {
+		model = DefaultHealthModel
 	}
+	analysis.Health = health
+	health.HealthScore, health.MaintenanceStatus, health.Contributions = model.Score(data, analysis)
 
 	return health
 }
 
 // GenerateReport gera um relatório completo de análise
 func GenerateReport(data *extractor.RepositoryData) string {
+	return GenerateTextReport(data, Analyze(data))
+}
+
+// GenerateTextReport gera o relatório em texto a partir de análises já calculadas
+func GenerateTextReport(data *extractor.RepositoryData, analysis *Analysis) string {
 	var report strings.Builder
 
 	report.WriteString("📊 RELATÓRIO COMPLETO DE ANÁLISE\n")
@@ -286,7 +267,7 @@ func GenerateReport(data *extractor.RepositoryData) string {
 	report.WriteString(fmt.Sprintf("🎯 Issues: %s\n\n", formatNumber(data.Statistics.Issues)))
 
 	// Análise de linguagens
-	languages := AnalyzeLanguages(data)
+	languages := analysis.Languages
 	if len(languages) > 0 {
 		report.WriteString("💻 DISTRIBUIÇÃO DE LINGUAGENS\n")
 		report.WriteString(strings.Repeat("-", 40) + "\n")
@@ -300,7 +281,7 @@ func GenerateReport(data *extractor.RepositoryData) string {
 	}
 
 	// Análise de atividade
-	activity := AnalyzeActivity(data)
+	activity := analysis.Activity
 	report.WriteString("⚡ ATIVIDADE RECENTE\n")
 	report.WriteString(strings.Repeat("-", 40) + "\n")
 	report.WriteString(fmt.Sprintf("Commits (última semana): %d\n", activity.CommitsLastWeek))
@@ -311,7 +292,7 @@ func GenerateReport(data *extractor.RepositoryData) string {
 	report.WriteString(fmt.Sprintf("Idade média dos PRs: %.1f dias\n\n", activity.AvgPRAge))
 
 	// Análise de colaboradores
-	contributors := AnalyzeContributors(data)
+	contributors := analysis.Contributors
 	report.WriteString("👥 COLABORADORES\n")
 	report.WriteString(strings.Repeat("-", 40) + "\n")
 	report.WriteString(fmt.Sprintf("Total de colaboradores: %d\n", contributors.TotalContributors))
@@ -326,15 +307,27 @@ func GenerateReport(data *extractor.RepositoryData) string {
 	report.WriteString("\n")
 
 	// Análise de saúde
-	health := AnalyzeHealth(data)
+	health := analysis.Health
 	report.WriteString("🏥 SAÚDE DO REPOSITÓRIO\n")
 	report.WriteString(strings.Repeat("-", 40) + "\n")
 	report.WriteString(fmt.Sprintf("Score de saúde: %.1f/100\n", health.HealthScore))
 	report.WriteString(fmt.Sprintf("Status: %s\n", health.MaintenanceStatus))
 	report.WriteString(fmt.Sprintf("Último commit: %d dias atrás\n", health.LastCommitDays))
 	report.WriteString(fmt.Sprintf("Último release: %d dias atrás\n", health.LastReleaseDays))
-	report.WriteString(fmt.Sprintf("Issues obsoletas: %d\n", health.StaleIssues))
-	report.WriteString(fmt.Sprintf("Ratio de issues abertas: %.1f%%\n\n", health.OpenIssuesRatio*100))
+	report.WriteString(fmt.Sprintf("Issues obsoletas: %d (%s)\n", health.StaleIssues, health.StaleIssuesBasis))
+	report.WriteString(fmt.Sprintf("Ratio de issues abertas: %.1f%% (%s)\n", health.OpenIssuesRatio*100, health.OpenIssuesRatioBasis))
+	report.WriteString("Contribuição de cada sinal (score inicial 100):\n")
+	for _, c := range health.Contributions {
+		condition := "sem penalidade"
+		switch {
+		case c.NoData:
+			condition = "sem dados"
+		case c.Condition != "":
+			condition = c.Condition
+		}
+		report.WriteString(fmt.Sprintf("  %+6.1f  %s (%s) — %s\n", c.Points, c.Label, condition, c.Basis))
+	}
+	report.WriteString("\n")
 
 	// Releases recentes
 	if len(data.Releases) > 0 {
@@ -352,6 +345,9 @@ func GenerateReport(data *extractor.RepositoryData) string {
 		report.WriteString("\n")
 	}
 
+	// Seções de indicadores das análises
+	writeTextSections(&report, analysis.sections())
+
 	report.WriteString(strings.Repeat("=", 80) + "\n")
 	report.WriteString(fmt.Sprintf("Relatório gerado em: %s\n", time.Now().Format("02/01/2006 15:04:05")))
 
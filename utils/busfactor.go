@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+const (
+	// truckFactorShare é a fatia dos commits que, concentrada em poucas
+	// pessoas, define o truck factor
+	truckFactorShare = 0.5
+	// coreCommittersShare é a fatia dos commits coberta pelo núcleo
+	coreCommittersShare = 0.8
+	// concentrationWeeks limita o cálculo de concentração à atividade recente,
+	// para que autores que já saíram do projeto não contem como conhecimento
+	concentrationWeeks = 52
+	// churnWindowWeeks é a janela usada para classificar os colaboradores em
+	// novos, recorrentes e inativos
+	churnWindowWeeks = 12
+)
+
+// Origens dos dados do bus factor
+const (
+	// BusFactorSourceStats usa os commits semanais do endpoint de estatísticas
+	BusFactorSourceStats = "stats"
+	// BusFactorSourceContributors usa o total de contribuições da lista de
+	// colaboradores (sem histórico, então sem novos/recorrentes/inativos)
+	BusFactorSourceContributors = "contributors"
+)
+
+// topShareSizes define os grupos de maiores colaboradores reportados
+var topShareSizes = []int{1, 3, 5, 10}
+
+// BusFactorAnalysis mede a concentração de conhecimento entre colaboradores
+type BusFactorAnalysis struct {
+	Source       string `json:"source"`
+	PeriodWeeks  int    `json:"period_weeks,omitempty"`
+	Contributors int    `json:"contributors"`
+	TotalCommits int    `json:"total_commits"`
+	TruckFactor  int    `json:"truck_factor"`
+	// CoreCommitters é o menor grupo responsável por coreCommittersShare dos
+	// commits do período
+	CoreCommitters int         `json:"core_committers"`
+	Gini           float64     `json:"gini"`
+	TopShares      []*TopShare `json:"top_shares"`
+
+	// Movimentação de colaboradores (apenas com o endpoint de estatísticas)
+	WindowWeeks int `json:"window_weeks,omitempty"`
+	Active      int `json:"active_contributors"`
+	New         int `json:"new_contributors"`
+	Returning   int `json:"returning_contributors"`
+	Churned     int `json:"churned_contributors"`
+}
+
+// TopShare é a fatia dos commits feita pelos N maiores colaboradores
+type TopShare struct {
+	Top   int     `json:"top"`
+	Share float64 `json:"share"`
+}
+
+// AnalyzeBusFactor calcula truck factor, coeficiente de Gini e fatia dos
+// maiores colaboradores. Com a atividade semanal disponível, considera apenas
+// as últimas concentrationWeeks semanas e classifica os colaboradores da janela
+// atual em novos, recorrentes e inativos.
+func AnalyzeBusFactor(data *extractor.RepositoryData) *BusFactorAnalysis {
+	analysis := &BusFactorAnalysis{Source: BusFactorSourceContributors}
+
+	var commits []int
+	if len(data.ContributorActivity) > 0 {
+		analysis.Source = BusFactorSourceStats
+		analysis.PeriodWeeks = concentrationWeeks
+		commits = recentCommitCounts(data.ContributorActivity, time.Now().AddDate(0, 0, -7*concentrationWeeks))
+		if sum(commits) == 0 {
+			// Sem commits no período, usa o histórico completo
+			analysis.PeriodWeeks = 0
+			commits = commits[:0]
+			for _, activity := range data.ContributorActivity {
+				commits = append(commits, activity.Total)
+			}
+		}
+		classifyContributors(analysis, data.ContributorActivity, time.Now())
+	} else {
+		for _, contrib := range data.Contributors {
+			commits = append(commits, contrib.Contributions)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(commits)))
+	for len(commits) > 0 && commits[len(commits)-1] == 0 {
+		commits = commits[:len(commits)-1]
+	}
+
+	analysis.Contributors = len(commits)
+	analysis.TotalCommits = sum(commits)
+	if analysis.TotalCommits == 0 {
+		return analysis
+	}
+
+	analysis.TruckFactor = coverage(commits, truckFactorShare)
+	analysis.CoreCommitters = coverage(commits, coreCommittersShare)
+	analysis.Gini = gini(commits)
+	for _, top := range topShareSizes {
+		if top > len(commits) {
+			break
+		}
+		analysis.TopShares = append(analysis.TopShares, &TopShare{
+			Top:   top,
+			Share: float64(sum(commits[:top])) / float64(analysis.TotalCommits),
+		})
+	}
+
+	return analysis
+}
+
+// classifyContributors compara a janela atual com a anterior: novos fizeram
+// o primeiro commit na janela atual, recorrentes voltaram após ficar fora da
+// janela anterior e inativos contribuíram na janela anterior mas não na atual
+func classifyContributors(analysis *BusFactorAnalysis, activities []*extractor.ContributorActivity, now time.Time) {
+	analysis.WindowWeeks = churnWindowWeeks
+	currentStart := now.AddDate(0, 0, -7*churnWindowWeeks)
+	previousStart := now.AddDate(0, 0, -14*churnWindowWeeks)
+
+	for _, activity := range activities {
+		var first time.Time
+		var current, previous bool
+		for _, week := range activity.Weeks {
+			if first.IsZero() || week.Week.Before(first) {
+				first = week.Week
+			}
+			switch {
+			case !week.Week.Before(currentStart):
+				current = true
+			case !week.Week.Before(previousStart):
+				previous = true
+			}
+		}
+
+		switch {
+		case current && !first.Before(currentStart):
+			analysis.Active++
+			analysis.New++
+		case current && !previous:
+			analysis.Active++
+			analysis.Returning++
+		case current:
+			analysis.Active++
+		case previous:
+			analysis.Churned++
+		}
+	}
+}
+
+// recentCommitCounts soma os commits de cada colaborador a partir de since
+func recentCommitCounts(activities []*extractor.ContributorActivity, since time.Time) []int {
+	counts := make([]int, 0, len(activities))
+	for _, activity := range activities {
+		total := 0
+		for _, week := range activity.Weeks {
+			if !week.Week.Before(since) {
+				total += week.Commits
+			}
+		}
+		counts = append(counts, total)
+	}
+	return counts
+}
+
+// coverage retorna quantos dos maiores valores (ordenados de forma
+// decrescente) são necessários para ultrapassar a fatia informada do total
+func coverage(sorted []int, share float64) int {
+	total := float64(sum(sorted))
+	accumulated := 0
+	for i, value := range sorted {
+		accumulated += value
+		if float64(accumulated) > total*share {
+			return i + 1
+		}
+	}
+	return len(sorted)
+}
+
+// gini calcula o coeficiente de Gini (0 = commits igualmente distribuídos,
+// perto de 1 = concentrados em uma pessoa)
+func gini(values []int) float64 {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+
+	ascending := make([]int, n)
+	copy(ascending, values)
+	sort.Ints(ascending)
+
+	var weighted, total float64
+	for i, value := range ascending {
+		weighted += float64(i+1) * float64(value)
+		total += float64(value)
+	}
+	if total == 0 {
+		return 0
+	}
+	return 2*weighted/(float64(n)*total) - float64(n+1)/float64(n)
+}
+
+// sum soma uma lista de inteiros
+func sum(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+// sourceDescription descreve em uma linha os dados usados no cálculo
+func (b *BusFactorAnalysis) sourceDescription() string {
+	switch {
+	case b.Source == BusFactorSourceContributors:
+		return fmt.Sprintf("total de contribuições de %d colaboradores (sem histórico semanal)", b.Contributors)
+	case b.PeriodWeeks > 0:
+		return fmt.Sprintf("%d commits de %d colaboradores nas últimas %d semanas", b.TotalCommits, b.Contributors, b.PeriodWeeks)
+	default:
+		return fmt.Sprintf("%d commits de %d colaboradores (histórico completo)", b.TotalCommits, b.Contributors)
+	}
+}
+
+// movementText resume a movimentação de colaboradores na janela
+func (b *BusFactorAnalysis) movementText() string {
+	return fmt.Sprintf("%d ativos · %d novos · %d recorrentes · %d inativos",
+		b.Active, b.New, b.Returning, b.Churned)
+}
+
+func (b *BusFactorAnalysis) sectionTitle() string { return "🚌 Concentração de conhecimento" }
+
+// sectionLines resume a concentração dos commits e a movimentação de
+// colaboradores; sem commits não há o que exibir
+func (b *BusFactorAnalysis) sectionLines() [][2]string {
+	if b.TotalCommits == 0 {
+		return nil
+	}
+
+	lines := [][2]string{
+		{"Truck factor (50% dos commits)", fmt.Sprintf("%d", b.TruckFactor)},
+		{"Núcleo (80% dos commits)", fmt.Sprintf("%d", b.CoreCommitters)},
+		{"Coeficiente de Gini", fmt.Sprintf("%.2f", b.Gini)},
+	}
+	for _, top := range b.TopShares {
+		lines = append(lines, [2]string{fmt.Sprintf("Fatia do top %d", top.Top), fmt.Sprintf("%.1f%%", top.Share*100)})
+	}
+	if b.WindowWeeks > 0 {
+		lines = append(lines, [2]string{fmt.Sprintf("Últimas %d semanas", b.WindowWeeks), b.movementText()})
+	}
+	return append(lines, [2]string{"Base", b.sourceDescription()})
+}
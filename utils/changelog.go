@@ -0,0 +1,488 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// Grupos do changelog além dos tipos de ConventionalTypes
+const (
+	ChangelogBreaking = "breaking"
+	ChangelogOther    = "other"
+)
+
+// changelogTitles são os títulos das seções do changelog por grupo
+var changelogTitles = map[string]string{
+	ChangelogBreaking: "💥 Quebras de compatibilidade",
+	"feat":            "✨ Novidades",
+	"fix":             "🐛 Correções",
+	"perf":            "⚡ Performance",
+	"refactor":        "♻️ Refatorações",
+	"revert":          "⏪ Reversões",
+	"docs":            "📝 Documentação",
+	"style":           "🎨 Estilo",
+	"test":            "✅ Testes",
+	"build":           "📦 Build e dependências",
+	"ci":              "👷 CI",
+	"chore":           "🔧 Manutenção",
+	ChangelogOther:    "📌 Outras mudanças",
+}
+
+// changelogLabelTypes associa labels comuns de PR aos tipos do changelog; o
+// label é comparado sem prefixos como "type:" ou "kind/"
+var changelogLabelTypes = map[string]string{
+	"bug": "fix", "fix": "fix", "bugfix": "fix",
+	"enhancement": "feat", "feature": "feat", "feat": "feat",
+	"documentation": "docs", "docs": "docs",
+	"dependencies": "build", "deps": "build", "build": "build",
+	"performance": "perf", "perf": "perf",
+	"refactor": "refactor", "refactoring": "refactor",
+	"test": "test", "tests": "test", "testing": "test",
+	"ci": "ci", "chore": "chore", "maintenance": "chore",
+	"breaking": ChangelogBreaking, "breaking-change": ChangelogBreaking, "breaking change": ChangelogBreaking,
+}
+
+// Changelog agrupa as mudanças entre duas refs por tipo
+type Changelog struct {
+	FullName     string                  `json:"full_name"`
+	From         string                  `json:"from"`
+	To           string                  `json:"to"`
+	CompareURL   string                  `json:"compare_url"`
+	TotalCommits int                     `json:"total_commits"`
+	ReadCommits  int                     `json:"read_commits"`
+	PullRequests int                     `json:"pull_requests"`
+	Groups       []*ChangelogGroup       `json:"groups"`
+	Contributors []*ChangelogContributor `json:"contributors"`
+	GeneratedAt  time.Time               `json:"generated_at"`
+}
+
+// ChangelogGroup é uma seção do changelog
+type ChangelogGroup struct {
+	Type    string            `json:"type"`
+	Title   string            `json:"title"`
+	Entries []*ChangelogEntry `json:"entries"`
+}
+
+// ChangelogEntry é uma mudança: um PR mergeado ou um commit sem PR
+type ChangelogEntry struct {
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+	Login       bool   `json:"login"`
+	// Ref é "#número" para PRs e o SHA abreviado para commits
+	Ref  string `json:"ref"`
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+// ChangelogContributor soma as contribuições de um autor entre as refs
+type ChangelogContributor struct {
+	Name         string `json:"name"`
+	Login        bool   `json:"login"`
+	Commits      int    `json:"commits"`
+	PullRequests int    `json:"pull_requests"`
+	FirstTime    bool   `json:"first_time"`
+	// FirstRef e FirstURL apontam a primeira contribuição de um novo colaborador
+	FirstRef string `json:"first_ref,omitempty"`
+	FirstURL string `json:"first_url,omitempty"`
+}
+
+// ChangelogRange completa as refs não informadas a partir dos releases: o
+// destino padrão é o último release estável e a origem padrão é a versão
+// anterior ao destino (ou o último release estável, quando o destino não é
+// um release, como em "main")
+func ChangelogRange(releases []*extractor.ReleaseData, from, to string) (string, string, error) {
+	var published []*extractor.ReleaseData
+	for _, release := range releases {
+		if !release.Draft {
+			published = append(published, release)
+		}
+	}
+
+	if to == "" {
+		latest := latestStableRelease(published, "")
+		if latest == nil {
+			return "", "", fmt.Errorf("nenhum release publicado; informe as refs do changelog")
+		}
+		to = latest.TagName
+	}
+	if from != "" {
+		return from, to, nil
+	}
+
+	var target *extractor.ReleaseData
+	for _, release := range published {
+		if release.TagName == to {
+			target = release
+		}
+	}
+	if target == nil {
+		if latest := latestStableRelease(published, to); latest != nil {
+			return latest.TagName, to, nil
+		}
+		return "", "", fmt.Errorf("nenhum release publicado antes de %s; informe a ref inicial", to)
+	}
+
+	if previous := previousRelease(published, target); previous != nil {
+		return previous.TagName, to, nil
+	}
+	return "", "", fmt.Errorf("nenhum release anterior a %s; informe a ref inicial", to)
+}
+
+// latestStableRelease retorna o release estável publicado mais recentemente,
+// ignorando a tag informada
+func latestStableRelease(releases []*extractor.ReleaseData, skip string) *extractor.ReleaseData {
+	var latest *extractor.ReleaseData
+	for _, release := range releases {
+		if release.Prerelease || release.TagName == skip {
+			continue
+		}
+		if latest == nil || release.PublishedAt.After(latest.PublishedAt) {
+			latest = release
+		}
+	}
+	return latest
+}
+
+// previousRelease retorna a maior versão menor que a do release alvo (sem
+// pré-releases quando o alvo é estável) ou, sem semver, o release publicado
+// imediatamente antes
+func previousRelease(releases []*extractor.ReleaseData, target *extractor.ReleaseData) *extractor.ReleaseData {
+	var previous *extractor.ReleaseData
+	if version, ok := ParseSemVer(target.TagName); ok {
+		stable := !target.Prerelease && version.Prerelease == ""
+		var best *SemVer
+		for _, release := range releases {
+			candidate, ok := ParseSemVer(release.TagName)
+			if !ok || candidate.Compare(version) >= 0 {
+				continue
+			}
+			if stable && (release.Prerelease || candidate.Prerelease != "") {
+				continue
+			}
+			if best == nil || candidate.Compare(best) > 0 {
+				best, previous = candidate, release
+			}
+		}
+		return previous
+	}
+
+	for _, release := range releases {
+		if release == target || !release.PublishedAt.Before(target.PublishedAt) {
+			continue
+		}
+		if previous == nil || release.PublishedAt.After(previous.PublishedAt) {
+			previous = release
+		}
+	}
+	return previous
+}
+
+// BuildChangelog agrupa os PRs mergeados e os commits sem PR pelo tipo
+// Conventional Commit do título ou, sem ele, pelos labels do PR, e soma as
+// contribuições de cada autor
+func BuildChangelog(data *extractor.ChangelogData) *Changelog {
+	changelog := &Changelog{
+		FullName:     data.Owner + "/" + data.Repo,
+		From:         data.From,
+		To:           data.To,
+		CompareURL:   data.CompareURL,
+		TotalCommits: data.TotalCommits,
+		ReadCommits:  len(data.Commits),
+		GeneratedAt:  data.GeneratedAt,
+	}
+
+	var entries []*ChangelogEntry
+	pulls := make(map[int]bool)
+	for _, pull := range data.PullRequests {
+		pulls[pull.Number] = true
+		entry := &ChangelogEntry{Author: pull.Author, Login: true, Ref: fmt.Sprintf("#%d", pull.Number), URL: pull.URL}
+		classifyChangelogEntry(entry, pull.Title, pull.Body, pull.Labels)
+		entries = append(entries, entry)
+	}
+
+	// PRs conhecidos apenas pelo número da mensagem (limite de consultas ou erro)
+	fallback := make(map[int][]*extractor.ChangelogCommit)
+	var order []int
+	for _, commit := range data.Commits {
+		switch {
+		case commit.PullRequest > 0 && !pulls[commit.PullRequest]:
+			if len(fallback[commit.PullRequest]) == 0 {
+				order = append(order, commit.PullRequest)
+			}
+			fallback[commit.PullRequest] = append(fallback[commit.PullRequest], commit)
+		case commit.PullRequest == 0 && !commit.Merge:
+			entry := &ChangelogEntry{Author: commit.Author, Login: commit.Login, Ref: shortSHA(commit.SHA), URL: commit.URL}
+			classifyChangelogEntry(entry, commitSubject(commit.Message), commit.Message, nil)
+			entries = append(entries, entry)
+		}
+	}
+	for _, number := range order {
+		commit, title := fallbackPullTitle(fallback[number])
+		entry := &ChangelogEntry{
+			Author: commit.Author,
+			Login:  commit.Login,
+			Ref:    fmt.Sprintf("#%d", number),
+			URL:    fmt.Sprintf("https://github.com/%s/pull/%d", changelog.FullName, number),
+		}
+		classifyChangelogEntry(entry, title, commit.Message, nil)
+		entries = append(entries, entry)
+	}
+	changelog.PullRequests = len(data.PullRequests) + len(order)
+
+	byType := make(map[string]*ChangelogGroup)
+	for _, entry := range entries {
+		group, ok := byType[entry.Type]
+		if !ok {
+			group = &ChangelogGroup{Type: entry.Type, Title: changelogTitles[entry.Type]}
+			byType[entry.Type] = group
+		}
+		group.Entries = append(group.Entries, entry)
+	}
+	groupOrder := append(append([]string{ChangelogBreaking}, ConventionalTypes...), ChangelogOther)
+	for _, groupType := range groupOrder {
+		if group, ok := byType[groupType]; ok {
+			changelog.Groups = append(changelog.Groups, group)
+		}
+	}
+
+	changelog.Contributors = changelogContributors(data)
+	return changelog
+}
+
+// classifyChangelogEntry define tipo, escopo e descrição da entrada pelo
+// título em Conventional Commits ou pelos labels; quebras de compatibilidade
+// ("!", rodapé BREAKING CHANGE ou label) vão para o grupo próprio
+func classifyChangelogEntry(entry *ChangelogEntry, title, body string, labels []string) {
+	entry.Type = ChangelogOther
+	entry.Description = strings.TrimSpace(title)
+	breaking := breakingFooterPattern.MatchString(body)
+
+	if commit, ok := ParseConventionalCommit(title); ok {
+		entry.Type, entry.Scope, entry.Description = commit.Type, commit.Scope, commit.Description
+		breaking = breaking || commit.Breaking
+	}
+	for _, label := range labels {
+		name := strings.ToLower(strings.TrimSpace(label))
+		if idx := strings.LastIndexAny(name, ":/"); idx >= 0 {
+			name = strings.TrimSpace(name[idx+1:])
+		}
+		switch labelType := changelogLabelTypes[name]; {
+		case labelType == ChangelogBreaking:
+			breaking = true
+		case labelType != "" && entry.Type == ChangelogOther:
+			entry.Type = labelType
+		}
+	}
+
+	if breaking {
+		entry.Type = ChangelogBreaking
+	}
+}
+
+// fallbackPullTitle escolhe o título de um PR conhecido só pelos commits: o
+// corpo do commit de merge ("Merge pull request #1 from ...\n\nTítulo") ou o
+// assunto do commit de squash, sem o sufixo "(#1)"
+func fallbackPullTitle(commits []*extractor.ChangelogCommit) (*extractor.ChangelogCommit, string) {
+	for _, commit := range commits {
+		if !commit.Merge {
+			continue
+		}
+		_, body, _ := strings.Cut(commit.Message, "\n")
+		if title := commitSubject(strings.TrimSpace(body)); title != "" {
+			return commit, title
+		}
+	}
+
+	commit := commits[0]
+	subject := commitSubject(commit.Message)
+	if idx := strings.LastIndex(subject, " (#"); idx > 0 {
+		subject = subject[:idx]
+	}
+	return commit, subject
+}
+
+// changelogContributors soma commits e PRs por autor, do que mais contribuiu
+// para o que menos contribuiu, marcando quem contribui pela primeira vez
+func changelogContributors(data *extractor.ChangelogData) []*ChangelogContributor {
+	first := make(map[string]bool)
+	for _, login := range data.FirstTimeContributors {
+		first[login] = true
+	}
+
+	byName := make(map[string]*ChangelogContributor)
+	var contributors []*ChangelogContributor
+	contributor := func(name string, login bool) *ChangelogContributor {
+		if existing, ok := byName[name]; ok {
+			return existing
+		}
+		created := &ChangelogContributor{Name: name, Login: login, FirstTime: login && first[name]}
+		byName[name] = created
+		contributors = append(contributors, created)
+		return created
+	}
+
+	for _, commit := range data.Commits {
+		if commit.Author == "" {
+			continue
+		}
+		author := contributor(commit.Author, commit.Login)
+		author.Commits++
+		if author.FirstTime && author.FirstRef == "" && commit.PullRequest == 0 {
+			author.FirstRef, author.FirstURL = shortSHA(commit.SHA), commit.URL
+		}
+	}
+
+	pulls := append([]*extractor.ChangelogPullRequest(nil), data.PullRequests...)
+	sort.SliceStable(pulls, func(i, j int) bool { return pulls[i].MergedAt.Before(pulls[j].MergedAt) })
+	for _, pull := range pulls {
+		if pull.Author == "" {
+			continue
+		}
+		author := contributor(pull.Author, true)
+		author.PullRequests++
+		// O primeiro PR mergeado tem precedência sobre commits diretos
+		if author.FirstTime && (author.FirstRef == "" || author.PullRequests == 1) {
+			author.FirstRef, author.FirstURL = fmt.Sprintf("#%d", pull.Number), pull.URL
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return strings.ToLower(contributors[i].Name) < strings.ToLower(contributors[j].Name)
+	})
+	return contributors
+}
+
+// FirstTimeContributors retorna os colaboradores que contribuem pela primeira vez
+func (c *Changelog) FirstTimeContributors() []*ChangelogContributor {
+	var firstTime []*ChangelogContributor
+	for _, contributor := range c.Contributors {
+		if contributor.FirstTime {
+			firstTime = append(firstTime, contributor)
+		}
+	}
+	return firstTime
+}
+
+// authorMention formata o autor como menção (@login) ou pelo nome do git
+func authorMention(name string, login bool) string {
+	if login {
+		return "@" + name
+	}
+	return escapeMarkdownText(name)
+}
+
+// markdownControlChars são os caracteres que abrem formatação inline no
+// Markdown: links, ênfase, código, HTML e separadores de tabela
+const markdownControlChars = "\\`*_[]<>|"
+
+// escapeMarkdownText escapa textos vindos do GitHub (títulos de PR, escopos e
+// nomes de autores) para que apareçam literalmente no changelog; um "#" no
+// início também é escapado e quebras de linha viram espaços
+func escapeMarkdownText(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+
+	var sb strings.Builder
+	for i, r := range value {
+		if strings.ContainsRune(markdownControlChars, r) || (i == 0 && r == '#') {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// markdownCode formata uma ref como código inline: a cerca tem mais crases do
+// que a maior sequência delas no valor, que ganha espaços nas pontas quando
+// começa ou termina com crase
+func markdownCode(value string) string {
+	longest, run := 0, 0
+	for _, r := range value {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	if strings.HasPrefix(value, "`") || strings.HasSuffix(value, "`") {
+		value = " " + value + " "
+	}
+	fence := strings.Repeat("`", longest+1)
+	return fence + value + fence
+}
+
+// RenderMarkdown renderiza o changelog em Markdown, pronto para as notas do release
+func (c *Changelog) RenderMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# 📋 Changelog de %s: %s...%s\n\n", c.FullName, markdownCode(c.From), markdownCode(c.To)))
+
+	firstTime := c.FirstTimeContributors()
+	summary := fmt.Sprintf("%d commits · %d pull requests · %d colaboradores", c.TotalCommits, c.PullRequests, len(c.Contributors))
+	if len(firstTime) > 0 {
+		summary += fmt.Sprintf(" (%d novos)", len(firstTime))
+	}
+	if c.CompareURL != "" {
+		summary = fmt.Sprintf("[Comparação completa](%s) · %s", c.CompareURL, summary)
+	}
+	sb.WriteString(summary + "\n\n")
+	if c.ReadCommits < c.TotalCommits {
+		sb.WriteString(fmt.Sprintf("> ⚠️ A comparação tem %d commits; o changelog considera os primeiros %d.\n\n",
+			c.TotalCommits, c.ReadCommits))
+	}
+
+	if len(c.Groups) == 0 {
+		sb.WriteString("_Nenhuma mudança entre as refs._\n\n")
+	}
+	for _, group := range c.Groups {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", group.Title))
+		for _, entry := range group.Entries {
+			line := "- "
+			if entry.Scope != "" {
+				line += fmt.Sprintf("**%s:** ", escapeMarkdownText(entry.Scope))
+			}
+			line += fmt.Sprintf("%s ([%s](%s))", escapeMarkdownText(entry.Description), entry.Ref, entry.URL)
+			if entry.Author != "" {
+				line += " por " + authorMention(entry.Author, entry.Login)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(c.Contributors) > 0 {
+		sb.WriteString("## 👥 Colaboradores\n\n")
+		parts := make([]string, len(c.Contributors))
+		for i, contributor := range c.Contributors {
+			counts := fmt.Sprintf("%d commits", contributor.Commits)
+			if contributor.PullRequests > 0 {
+				counts += fmt.Sprintf(", %d PRs", contributor.PullRequests)
+			}
+			parts[i] = fmt.Sprintf("%s (%s)", authorMention(contributor.Name, contributor.Login), counts)
+		}
+		sb.WriteString(strings.Join(parts, " · ") + "\n\n")
+	}
+
+	if len(firstTime) > 0 {
+		sb.WriteString("## 🎉 Novos colaboradores\n\n")
+		for _, contributor := range firstTime {
+			line := "- " + authorMention(contributor.Name, true) + " fez a primeira contribuição"
+			if contributor.FirstRef != "" {
+				line += fmt.Sprintf(" em [%s](%s)", contributor.FirstRef, contributor.FirstURL)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("_Gerado em %s._\n", c.GeneratedAt.Format("02/01/2006 15:04:05")))
+	return sb.String()
+}
@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+func TestEscapeMarkdownText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"corrige o parser", "corrige o parser"},
+		{"suporte a a|b em tabelas", `suporte a a\|b em tabelas`},
+		{"[WIP] novo exportador", `\[WIP\] novo exportador`},
+		{"# 1 da lista", `\# 1 da lista`},
+		{"corrige #45", "corrige #45"},
+		{"usa *ptr e __init__", `usa \*ptr e \_\_init\_\_`},
+		{"escapa `<div>`", "escapa \\`\\<div\\>\\`"},
+		{`caminho C:\tmp`, `caminho C:\\tmp`},
+		{"título\nem duas linhas", "título em duas linhas"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeMarkdownText(tt.value); got != tt.want {
+			t.Errorf("escapeMarkdownText(%q) = %q, esperado %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMarkdownEscapesEntries(t *testing.T) {
+	changelog := &Changelog{
+		FullName: "octo/app",
+		From:     "v1.0.0",
+		To:       "v1.1.0",
+		Groups: []*ChangelogGroup{{
+			Title: "🐛 Correções",
+			Entries: []*ChangelogEntry{
+				{Scope: "a|b", Description: "[cli] trata # no | argumento", Author: "dev-1", Login: true, Ref: "#7", URL: "https://github.com/octo/app/pull/7"},
+				{Description: "# regressão", Author: "Ana [bot]", Ref: "abc1234", URL: "https://github.com/octo/app/commit/abc1234"},
+			},
+		}},
+		Contributors: []*ChangelogContributor{{Name: "Ana [bot]", Commits: 1}},
+	}
+
+	markdown := changelog.RenderMarkdown()
+	for _, want := range []string{
+		`- **a\|b:** \[cli\] trata # no \| argumento ([#7](https://github.com/octo/app/pull/7)) por @dev-1`,
+		`- \# regressão ([abc1234](https://github.com/octo/app/commit/abc1234)) por Ana \[bot\]`,
+		`Ana \[bot\] (1 commits)`,
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("changelog não contém %q:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestRenderMarkdownEscapesRefs(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     string
+	}{
+		{"v1.0.0", "v1.1.0", "`v1.0.0`...`v1.1.0`"},
+		{"a`b", "main", "``a`b``...`main`"},
+		{"`v1`", "x``y", "`` `v1` ``...```x``y```"},
+	}
+
+	for _, tt := range tests {
+		heading, _, _ := strings.Cut((&Changelog{FullName: "octo/app", From: tt.from, To: tt.to}).RenderMarkdown(), "\n")
+		if want := "# 📋 Changelog de octo/app: " + tt.want; heading != want {
+			t.Errorf("título = %q, esperado %q", heading, want)
+		}
+	}
+}
+
+func TestBuildChangelog(t *testing.T) {
+	merged := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	data := &extractor.ChangelogData{
+		Owner: "octo",
+		Repo:  "app",
+		PullRequests: []*extractor.ChangelogPullRequest{
+			{Number: 1, Title: "feat(cli): novo comando", Author: "ana", MergedAt: merged, URL: "https://github.com/octo/app/pull/1"},
+			{Number: 2, Title: "Corrige timeout", Author: "ana", Labels: []string{"type: bug"}, MergedAt: merged, URL: "https://github.com/octo/app/pull/2"},
+			{Number: 3, Title: "feat!: remove a flag antiga", Author: "bia", MergedAt: merged, URL: "https://github.com/octo/app/pull/3"},
+			{Number: 4, Title: "Atualiza dependências", Author: "bia", Labels: []string{"kind/breaking-change", "dependencies"}, MergedAt: merged, URL: "https://github.com/octo/app/pull/4"},
+			{Number: 5, Title: "Melhora os logs", Author: "carla", MergedAt: merged.Add(time.Hour), URL: "https://github.com/octo/app/pull/5"},
+		},
+		Commits: []*extractor.ChangelogCommit{
+			{SHA: "1111111aaaa", Message: "feat(cli): novo comando", Author: "ana", Login: true, PullRequest: 1},
+			{SHA: "2222222bbbb", Message: "docs: atualiza o README", Author: "Bruno Silva", URL: "https://github.com/octo/app/commit/2222222bbbb"},
+			{SHA: "3333333cccc", Message: "Merge pull request #6 from dani/nil\n\nfix: trata resposta nula", Author: "dani", Login: true, Merge: true, PullRequest: 6},
+			{SHA: "4444444dddd", Message: "fix: trata resposta nula", Author: "dani", Login: true, PullRequest: 6},
+			{SHA: "5555555eeee", Message: "perf: cache das tags (#7)", Author: "ana", Login: true, PullRequest: 7},
+			{SHA: "6666666ffff", Message: "Merge branch 'main' into dev", Author: "ana", Login: true, Merge: true},
+			{SHA: "7777777aaaa", Message: "chore: ajusta o lint", Author: "carla", Login: true, URL: "https://github.com/octo/app/commit/7777777aaaa"},
+			{SHA: "8888888bbbb", Message: "test: cobre o parser", Author: "eva", Login: true, URL: "https://github.com/octo/app/commit/8888888bbbb"},
+		},
+		FirstTimeContributors: []string{"carla", "eva", "Bruno Silva"},
+	}
+
+	changelog := BuildChangelog(data)
+
+	var groups []string
+	refs := make(map[string][]string)
+	for _, group := range changelog.Groups {
+		groups = append(groups, group.Type)
+		for _, entry := range group.Entries {
+			refs[group.Type] = append(refs[group.Type], entry.Ref)
+		}
+	}
+	if want := []string{ChangelogBreaking, "feat", "fix", "perf", "docs", "test", "chore", ChangelogOther}; !slices.Equal(groups, want) {
+		t.Errorf("grupos = %v, esperado %v", groups, want)
+	}
+	for groupType, want := range map[string][]string{
+		ChangelogBreaking: {"#3", "#4"},
+		"feat":            {"#1"},
+		"fix":             {"#2", "#6"},
+		"perf":            {"#7"},
+		"docs":            {"2222222"},
+		ChangelogOther:    {"#5"},
+	} {
+		if !slices.Equal(refs[groupType], want) {
+			t.Errorf("refs de %s = %v, esperado %v", groupType, refs[groupType], want)
+		}
+	}
+	if changelog.PullRequests != 7 {
+		t.Errorf("PullRequests = %d, esperado 7", changelog.PullRequests)
+	}
+
+	entries := make(map[string]*ChangelogEntry)
+	for _, group := range changelog.Groups {
+		for _, entry := range group.Entries {
+			entries[entry.Ref] = entry
+		}
+	}
+	if entry := entries["#1"]; entry.Scope != "cli" || entry.Description != "novo comando" {
+		t.Errorf("#1 = %q/%q, esperado cli/novo comando", entry.Scope, entry.Description)
+	}
+	if entry := entries["#6"]; entry.Description != "trata resposta nula" || entry.Author != "dani" || entry.URL != "https://github.com/octo/app/pull/6" {
+		t.Errorf("#6 = %q por %q em %q", entry.Description, entry.Author, entry.URL)
+	}
+	if entry := entries["#7"]; entry.Description != "cache das tags" {
+		t.Errorf("#7 = %q, esperado sem o sufixo do squash", entry.Description)
+	}
+
+	first := make(map[string]string)
+	for _, contributor := range changelog.FirstTimeContributors() {
+		first[contributor.Name] = contributor.FirstRef
+	}
+	// Nomes do git (sem login) não são marcados como novos colaboradores
+	if want := map[string]string{"carla": "#5", "eva": "8888888"}; !maps.Equal(first, want) {
+		t.Errorf("novos colaboradores = %v, esperado %v", first, want)
+	}
+}
+
+func TestChangelogRange(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 1, n, 0, 0, 0, 0, time.UTC) }
+	releases := []*extractor.ReleaseData{
+		{TagName: "v2.0.0", PublishedAt: day(25), Draft: true},
+		{TagName: "v1.1.1", PublishedAt: day(20)},
+		{TagName: "v1.2.0-rc.1", PublishedAt: day(15), Prerelease: true},
+		{TagName: "v1.1.0", PublishedAt: day(10)},
+		{TagName: "v1.0.0", PublishedAt: day(5)},
+	}
+
+	tests := []struct {
+		name     string
+		releases []*extractor.ReleaseData
+		from, to string
+		wantFrom string
+		wantTo   string
+		err      bool
+	}{
+		{"padrão: último estável e o anterior", releases, "", "", "v1.1.0", "v1.1.1", false},
+		{"origem informada", releases, "abc1234", "", "abc1234", "v1.1.1", false},
+		{"pré-release compara com a versão anterior", releases, "", "v1.2.0-rc.1", "v1.1.1", "v1.2.0-rc.1", false},
+		{"destino fora dos releases", releases, "", "main", "v1.1.1", "main", false},
+		{"rascunho não é release", releases, "", "v2.0.0", "v1.1.1", "v2.0.0", false},
+		{"primeiro release", releases, "", "v1.0.0", "", "", true},
+		{"sem releases", nil, "", "", "", "", true},
+		{"sem releases com destino", nil, "", "main", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ChangelogRange(tt.releases, tt.from, tt.to)
+			if (err != nil) != tt.err {
+				t.Fatalf("erro = %v, esperado erro: %v", err, tt.err)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("intervalo = %s...%s, esperado %s...%s", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
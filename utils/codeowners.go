@@ -0,0 +1,334 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github-octokit-poc/extractor"
+)
+
+const (
+	// codeownersUnownedSample limita os arquivos sem dono listados
+	codeownersUnownedSample = 20
+	// codeownersUnownedDirs limita os diretórios sem dono listados
+	codeownersUnownedDirs = 10
+)
+
+// ownerStatusLabels traduz a situação dos donos para exibição
+var ownerStatusLabels = map[string]string{
+	extractor.OwnerStatusOK:       "ok",
+	extractor.OwnerStatusNotFound: "não existe",
+	extractor.OwnerStatusNoWrite:  "sem permissão de escrita",
+	extractor.OwnerStatusInvalid:  "desconhecido para o GitHub",
+	extractor.OwnerStatusUnknown:  "não verificado",
+}
+
+// CodeOwnersAnalysis cruza as regras do CODEOWNERS com a árvore do repositório
+type CodeOwnersAnalysis struct {
+	Found bool   `json:"found"`
+	Path  string `json:"path,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Rules int    `json:"rules"`
+
+	// Cobertura (apenas com a árvore de arquivos disponível)
+	TreeAvailable  bool                         `json:"tree_available"`
+	TreeTruncated  bool                         `json:"tree_truncated"`
+	Files          int                          `json:"files"`
+	OwnedFiles     int                          `json:"owned_files"`
+	Coverage       float64                      `json:"coverage_percent"`
+	UnownedDirs    []*UnownedPath               `json:"unowned_dirs,omitempty"`
+	UnownedFiles   []string                     `json:"unowned_files,omitempty"`
+	UnmatchedRules []string                     `json:"unmatched_rules,omitempty"`
+	InvalidOwners  []*extractor.CodeOwner       `json:"invalid_owners,omitempty"`
+	Unverified     int                          `json:"unverified_owners"`
+	Errors         []*extractor.CodeOwnersError `json:"errors,omitempty"`
+}
+
+// UnownedPath conta os arquivos sem dono de um diretório de primeiro nível
+type UnownedPath struct {
+	Path  string `json:"path"`
+	Files int    `json:"files"`
+}
+
+// codeownersMatcher é uma regra do CODEOWNERS com o padrão compilado
+type codeownersMatcher struct {
+	rule    *extractor.CodeOwnersRule
+	pattern *regexp.Regexp
+	owned   bool
+	matched bool
+}
+
+// AnalyzeCodeOwners calcula a cobertura do CODEOWNERS sobre os arquivos do
+// branch padrão: como no GitHub, a última regra que casa com o arquivo define
+// os donos, e donos inexistentes ou sem escrita não contam. Retorna nil quando
+// o CODEOWNERS não foi verificado na extração.
+func AnalyzeCodeOwners(data *extractor.RepositoryData) *CodeOwnersAnalysis {
+	codeowners := data.CodeOwners
+	if codeowners == nil {
+		return nil
+	}
+
+	analysis := &CodeOwnersAnalysis{
+		Found:  codeowners.Found,
+		Path:   codeowners.Path,
+		URL:    codeowners.URL,
+		Rules:  len(codeowners.Rules),
+		Errors: codeowners.Errors,
+	}
+
+	invalid := make(map[string]bool)
+	for _, owner := range codeowners.Owners {
+		switch owner.Status {
+		case extractor.OwnerStatusOK:
+		case extractor.OwnerStatusUnknown:
+			analysis.Unverified++
+		default:
+			invalid[strings.ToLower(owner.Name)] = true
+			analysis.InvalidOwners = append(analysis.InvalidOwners, owner)
+		}
+	}
+
+	if data.Tree == nil {
+		return analysis
+	}
+	analysis.TreeAvailable = true
+	analysis.TreeTruncated = data.Tree.Truncated
+	analysis.Files = len(data.Tree.Files)
+
+	matchers := make([]*codeownersMatcher, 0, len(codeowners.Rules))
+	for _, rule := range codeowners.Rules {
+		matcher := &codeownersMatcher{rule: rule, pattern: codeownersPattern(rule.Pattern)}
+		for _, owner := range rule.Owners {
+			if !invalid[strings.ToLower(owner)] {
+				matcher.owned = true
+				break
+			}
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	unownedDirs := make(map[string]int)
+	for _, file := range data.Tree.Files {
+		owned := false
+		for i := len(matchers) - 1; i >= 0; i-- {
+			if matchers[i].pattern.MatchString(file.Path) {
+				matchers[i].matched = true
+				owned = matchers[i].owned
+				break
+			}
+		}
+		if owned {
+			analysis.OwnedFiles++
+			continue
+		}
+		unownedDirs[topLevelDir(file.Path)]++
+		if len(analysis.UnownedFiles) < codeownersUnownedSample {
+			analysis.UnownedFiles = append(analysis.UnownedFiles, file.Path)
+		}
+	}
+	if analysis.Files > 0 {
+		analysis.Coverage = float64(analysis.OwnedFiles) / float64(analysis.Files) * 100
+	}
+
+	for dir, files := range unownedDirs {
+		analysis.UnownedDirs = append(analysis.UnownedDirs, &UnownedPath{Path: dir, Files: files})
+	}
+	sort.Slice(analysis.UnownedDirs, func(i, j int) bool {
+		if analysis.UnownedDirs[i].Files != analysis.UnownedDirs[j].Files {
+			return analysis.UnownedDirs[i].Files > analysis.UnownedDirs[j].Files
+		}
+		return analysis.UnownedDirs[i].Path < analysis.UnownedDirs[j].Path
+	})
+	if len(analysis.UnownedDirs) > codeownersUnownedDirs {
+		analysis.UnownedDirs = analysis.UnownedDirs[:codeownersUnownedDirs]
+	}
+
+	// Com a árvore truncada, um padrão sem arquivos pode casar com o que faltou
+	if !analysis.TreeTruncated {
+		for _, matcher := range matchers {
+			if !matcher.matched {
+				analysis.UnmatchedRules = append(analysis.UnmatchedRules,
+					fmt.Sprintf("%s (linha %d)", matcher.rule.Pattern, matcher.rule.Line))
+			}
+		}
+	}
+
+	return analysis
+}
+
+// codeownersPattern converte um padrão do CODEOWNERS (sintaxe do .gitignore,
+// sem negação nem classes de caracteres) em expressão regular sobre o caminho.
+// Padrões com "/" no início ou no meio são relativos à raiz; os demais casam
+// em qualquer nível. Um padrão que casa com um diretório cobre tudo abaixo
+// dele, exceto quando termina em "/*" (apenas os arquivos do diretório).
+func codeownersPattern(pattern string) *regexp.Regexp {
+	pattern = strings.ReplaceAll(pattern, `\#`, "#")
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	segments := strings.Split(pattern, "/")
+	last := segments[len(segments)-1]
+	for i, segment := range segments {
+		isLast := i == len(segments)-1
+		if segment == "**" {
+			if isLast {
+				expr.WriteString(".*")
+			} else {
+				expr.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		for _, r := range segment {
+			switch r {
+			case '*':
+				expr.WriteString("[^/]*")
+			case '?':
+				expr.WriteString("[^/]")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		if !isLast {
+			expr.WriteString("/")
+		}
+	}
+
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*")
+	case last == "*" || last == "**":
+	default:
+		expr.WriteString("(?:/.*)?")
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String())
+}
+
+// topLevelDir retorna o diretório de primeiro nível do caminho
+func topLevelDir(path string) string {
+	if dir, _, found := strings.Cut(path, "/"); found {
+		return dir + "/"
+	}
+	return "(raiz)"
+}
+
+// summary resume a cobertura em uma linha
+func (c *CodeOwnersAnalysis) summary() string {
+	if !c.Found {
+		return "CODEOWNERS não encontrado (.github/, raiz ou docs/)"
+	}
+	text := fmt.Sprintf("%s com %d regras", c.Path, c.Rules)
+	if c.TreeAvailable {
+		text += fmt.Sprintf(" · %d de %d arquivos com dono (%.1f%%)", c.OwnedFiles, c.Files, c.Coverage)
+	}
+	if c.TreeTruncated {
+		text += " · árvore truncada pela API, cobertura parcial"
+	}
+	return text
+}
+
+// ownerText descreve um dono inválido
+func ownerText(owner *extractor.CodeOwner) string {
+	text := fmt.Sprintf("%s: %s", owner.Name, ownerStatusLabels[owner.Status])
+	if owner.Permission != "" && owner.Status == extractor.OwnerStatusNoWrite {
+		text += fmt.Sprintf(" (permissão: %s)", owner.Permission)
+	}
+	return text + fmt.Sprintf(", em %d regra(s)", owner.Rules)
+}
+
+// unownedDirsText lista os diretórios com arquivos sem dono
+func (c *CodeOwnersAnalysis) unownedDirsText() string {
+	parts := make([]string, len(c.UnownedDirs))
+	for i, dir := range c.UnownedDirs {
+		parts[i] = fmt.Sprintf("%s (%d)", dir.Path, dir.Files)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// codeownersErrorText descreve um erro reportado pelo GitHub
+func codeownersErrorText(err *extractor.CodeOwnersError) string {
+	text := fmt.Sprintf("linha %d: %s", err.Line, err.Kind)
+	if err.Suggestion != "" {
+		text += " (" + err.Suggestion + ")"
+	}
+	return text
+}
+
+func (c *CodeOwnersAnalysis) sectionTitle() string { return "🛡️ CODEOWNERS" }
+
+// sectionLines resume a cobertura e os diretórios sem dono
+func (c *CodeOwnersAnalysis) sectionLines() [][2]string {
+	lines := [][2]string{{"Resumo", c.summary()}}
+	if len(c.UnownedDirs) > 0 {
+		lines = append(lines, [2]string{"Sem dono por diretório", c.unownedDirsText()})
+	}
+	if c.Unverified > 0 {
+		lines = append(lines, [2]string{"Donos não verificados", fmt.Sprintf("%d", c.Unverified)})
+	}
+	return lines
+}
+
+// notes lista os donos inválidos, os padrões sem arquivos e os erros
+// reportados pelo GitHub
+func (c *CodeOwnersAnalysis) notes() []string {
+	var notes []string
+	for _, owner := range c.InvalidOwners {
+		notes = append(notes, "⚠️ Dono inválido "+ownerText(owner))
+	}
+	for _, rule := range c.UnmatchedRules {
+		notes = append(notes, "Padrão sem arquivos: "+rule)
+	}
+	for _, err := range c.Errors {
+		notes = append(notes, "❌ Erro: "+codeownersErrorText(err))
+	}
+	return notes
+}
+
+func (c *CodeOwnersAnalysis) textDetails() []string { return c.notes() }
+
+// markdownDetails lista as observações, com link para o arquivo
+func (c *CodeOwnersAnalysis) markdownDetails() string {
+	var md strings.Builder
+	if c.URL != "" {
+		md.WriteString(fmt.Sprintf("Arquivo: [%s](%s)\n\n", escapeMarkdownCell(c.Path), c.URL))
+	}
+	notes := c.notes()
+	for _, note := range notes {
+		md.WriteString("- " + escapeMarkdownCell(note) + "\n")
+	}
+	if len(notes) > 0 {
+		md.WriteString("\n")
+	}
+	return md.String()
+}
+
+// htmlDetails lista as observações
+func (c *CodeOwnersAnalysis) htmlDetails() string {
+	notes := c.notes()
+	if len(notes) == 0 {
+		return ""
+	}
+
+	var page strings.Builder
+	page.WriteString("<ul>")
+	for _, note := range notes {
+		page.WriteString(fmt.Sprintf("<li>%s</li>", html.EscapeString(note)))
+	}
+	page.WriteString("</ul>")
+	return page.String()
+}
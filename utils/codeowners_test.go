@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github-octokit-poc/extractor"
+)
+
+func TestCodeownersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// Sem "/" o padrão casa em qualquer nível
+		{"*", "main.go", true},
+		{"*", "cmd/main.go", true},
+		{"*.js", "app.js", true},
+		{"*.js", "src/web/app.js", true},
+		{"*.js", "app.jsx", false},
+		{"README.md", "docs/README.md", true},
+		{"docs/", "src/docs/guide.md", true},
+
+		// "/" no início ou no meio ancora na raiz
+		{"/README.md", "README.md", true},
+		{"/README.md", "docs/README.md", false},
+		{"/docs/", "docs/guide.md", true},
+		{"/docs/", "docs/api/v1.md", true},
+		{"/docs/", "src/docs/guide.md", false},
+		{"build/logs/", "build/logs/out.log", true},
+		{"build/logs/", "src/build/logs/out.log", false},
+
+		// Um diretório cobre tudo abaixo dele; "/" no fim exige diretório
+		{"apps", "apps/web/index.ts", true},
+		{"apps", "apps", true},
+		{"/docs/", "docs", false},
+
+		// "/*" no fim casa apenas os arquivos do próprio diretório
+		{"docs/*", "docs/guide.md", true},
+		{"docs/*", "docs/api/v1.md", false},
+
+		// "**" casa com qualquer quantidade de diretórios
+		{"**/logs", "logs/out.log", true},
+		{"**/logs", "a/b/logs/out.log", true},
+		{"docs/**", "docs/api/v1.md", true},
+		{"docs/**", "docs", false},
+		{"a/**/b", "a/b", true},
+		{"a/**/b", "a/x/y/b/file", true},
+		{"a/**/b", "c/a/b", false},
+
+		// "?" casa com um caractere, exceto "/"
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"a?b", "a/b", false},
+
+		// Metacaracteres de regex são literais e "\#" é um "#" escapado
+		{"a.b", "axb", false},
+		{"a+b", "a+b", true},
+		{`\#notes`, "#notes", true},
+	}
+
+	for _, tt := range tests {
+		if got := codeownersPattern(tt.pattern).MatchString(tt.path); got != tt.want {
+			t.Errorf("codeownersPattern(%q) casando %q = %v, esperado %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeCodeOwners(t *testing.T) {
+	files := func(paths ...string) *extractor.RepositoryTree {
+		tree := &extractor.RepositoryTree{}
+		for _, path := range paths {
+			tree.Files = append(tree.Files, &extractor.TreeFile{Path: path})
+		}
+		return tree
+	}
+
+	tests := []struct {
+		name      string
+		rules     []*extractor.CodeOwnersRule
+		owners    []*extractor.CodeOwner
+		tree      *extractor.RepositoryTree
+		owned     int
+		unowned   []string
+		unmatched []string
+	}{
+		{
+			name:    "última regra que casa define os donos",
+			rules:   []*extractor.CodeOwnersRule{{Line: 1, Pattern: "*", Owners: []string{"@org/all"}}, {Line: 2, Pattern: "/docs/"}},
+			tree:    files("main.go", "docs/guide.md", "docs/api.md"),
+			owned:   1,
+			unowned: []string{"docs/guide.md", "docs/api.md"},
+		},
+		{
+			name: "donos inválidos não contam",
+			rules: []*extractor.CodeOwnersRule{
+				{Line: 1, Pattern: "*.go", Owners: []string{"@ghost"}},
+				{Line: 2, Pattern: "*.md", Owners: []string{"@ghost", "@dev"}},
+			},
+			owners:  []*extractor.CodeOwner{{Name: "@Ghost", Status: extractor.OwnerStatusNotFound}, {Name: "@dev", Status: extractor.OwnerStatusOK}},
+			tree:    files("main.go", "README.md"),
+			owned:   1,
+			unowned: []string{"main.go"},
+		},
+		{
+			name:      "regras sem arquivos são reportadas",
+			rules:     []*extractor.CodeOwnersRule{{Line: 1, Pattern: "*.go", Owners: []string{"@dev"}}, {Line: 3, Pattern: "/web/", Owners: []string{"@dev"}}},
+			tree:      files("main.go"),
+			owned:     1,
+			unmatched: []string{"/web/ (linha 3)"},
+		},
+		{
+			name:  "com a árvore truncada regras sem arquivos não são reportadas",
+			rules: []*extractor.CodeOwnersRule{{Line: 1, Pattern: "*.go", Owners: []string{"@dev"}}, {Line: 3, Pattern: "/web/", Owners: []string{"@dev"}}},
+			tree:  &extractor.RepositoryTree{Truncated: true, Files: []*extractor.TreeFile{{Path: "main.go"}}},
+			owned: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &extractor.RepositoryData{
+				CodeOwners: &extractor.CodeOwnersFile{Found: true, Rules: tt.rules, Owners: tt.owners},
+				Tree:       tt.tree,
+			}
+			analysis := AnalyzeCodeOwners(data)
+			if analysis.OwnedFiles != tt.owned {
+				t.Errorf("OwnedFiles = %d, esperado %d", analysis.OwnedFiles, tt.owned)
+			}
+			if !slices.Equal(analysis.UnownedFiles, tt.unowned) {
+				t.Errorf("UnownedFiles = %v, esperado %v", analysis.UnownedFiles, tt.unowned)
+			}
+			if !slices.Equal(analysis.UnmatchedRules, tt.unmatched) {
+				t.Errorf("UnmatchedRules = %v, esperado %v", analysis.UnmatchedRules, tt.unmatched)
+			}
+		})
+	}
+
+	if AnalyzeCodeOwners(&extractor.RepositoryData{}) != nil {
+		t.Error("sem CODEOWNERS verificado a análise deveria ser nil")
+	}
+}
@@ -0,0 +1,305 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github-octokit-poc/extractor"
+)
+
+// subjectLengthLimit é o tamanho máximo recomendado do assunto do commit
+// (convenção do git e do GitHub, que trunca o assunto em 72 caracteres)
+const subjectLengthLimit = 72
+
+// nonConventionalSamples é o número de assuntos fora do padrão listados
+const nonConventionalSamples = 5
+
+// subjectLengthBuckets são as faixas de tamanho do assunto (maxLength 0:
+// sem limite)
+var subjectLengthBuckets = []struct {
+	label     string
+	maxLength int
+}{
+	{"até 50", 50},
+	{"51 a 72", subjectLengthLimit},
+	{"73 a 100", 100},
+	{"mais de 100", 0},
+}
+
+// mergeSubjectPrefixes identificam commits de merge quando os pais não são
+// conhecidos (commits recebidos por webhook)
+var mergeSubjectPrefixes = []string{"Merge pull request ", "Merge branch ", "Merge remote-tracking branch "}
+
+// coAuthorPattern reconhece os trailers de co-autoria
+var coAuthorPattern = regexp.MustCompile(`(?mi)^co-authored-by:\s*(.+?)\s*$`)
+
+// issueReferencePattern reconhece referências a issues e PRs do GitHub:
+// "#123", "owner/repo#123", "GH-123" e URLs de issues ou pull requests
+var issueReferencePattern = regexp.MustCompile(`(?i)(?:^|[^\w&/])(?:[\w.-]+/[\w.-]+)?#\d+\b|\bGH-\d+\b|github\.com/[\w.-]+/[\w.-]+/(?:issues|pull)/\d+`)
+
+// pullRequestNumberPattern reconhece os números de PR gerados pelo GitHub, que
+// não são referências escritas pelo autor: o sufixo "(#123)" dos squash
+// merges (também nas linhas que listam os commits do PR) e o assunto
+// "Merge pull request #123"
+var pullRequestNumberPattern = regexp.MustCompile(`(?m)\s*\(#\d+\)[ \t]*$|^Merge pull request #\d+`)
+
+// CommitHygiene resume a qualidade das mensagens e metadados dos commits recentes
+type CommitHygiene struct {
+	Commits    int     `json:"commits"`
+	Merges     int     `json:"merges"`
+	MergeRatio float64 `json:"merge_ratio"`
+	// Analyzed são os commits sem merge, base das métricas de mensagem (as
+	// mensagens de merge são geradas automaticamente)
+	Analyzed          int                `json:"analyzed"`
+	Conventional      int                `json:"conventional"`
+	ConventionalRatio float64            `json:"conventional_ratio"`
+	Breaking          int                `json:"breaking"`
+	ByType            []*CommitTypeCount `json:"by_type"`
+	// NonConventional são exemplos de assuntos fora do Conventional Commits
+	NonConventional []string `json:"non_conventional,omitempty"`
+
+	SubjectLengths      []*SubjectLengthCount `json:"subject_lengths"`
+	MedianSubjectLength int                   `json:"median_subject_length"`
+	// LongSubjects são os assuntos acima de subjectLengthLimit caracteres
+	LongSubjects int `json:"long_subjects"`
+
+	// VerificationKnown conta os commits com dados de verificação (commits
+	// recebidos por webhook não têm); Signed e Verified são sobre eles
+	VerificationKnown int     `json:"verification_known"`
+	Signed            int     `json:"signed"`
+	Verified          int     `json:"verified"`
+	VerifiedRatio     float64 `json:"verified_ratio"`
+
+	CoAuthored int `json:"co_authored"`
+	CoAuthors  int `json:"co_authors"`
+
+	// Unlinked são os commits sem merge que não citam issue ou PR
+	Unlinked      int     `json:"unlinked"`
+	UnlinkedRatio float64 `json:"unlinked_ratio"`
+}
+
+// CommitTypeCount conta os commits de um tipo Conventional Commit
+type CommitTypeCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// SubjectLengthCount conta os assuntos de uma faixa de tamanho
+type SubjectLengthCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// AnalyzeCommitHygiene avalia o histórico de commits: aderência ao
+// Conventional Commits, tamanho do assunto, merges, assinaturas verificadas,
+// co-autoria e referências a issues; retorna nil quando não há commits
+func AnalyzeCommitHygiene(data *extractor.RepositoryData) *CommitHygiene {
+	history := commitHistory(data)
+	if len(history) == 0 {
+		return nil
+	}
+
+	hygiene := &CommitHygiene{Commits: len(history)}
+	byType := make(map[string]int)
+	buckets := make([]int, len(subjectLengthBuckets))
+	coAuthors := make(map[string]bool)
+	var lengths []int
+	for _, commit := range history {
+		if commit.Verification != "" {
+			hygiene.VerificationKnown++
+			if commit.Verification != "unsigned" {
+				hygiene.Signed++
+			}
+			if commit.Verified {
+				hygiene.Verified++
+			}
+		}
+
+		if matches := coAuthorPattern.FindAllStringSubmatch(commit.Message, -1); len(matches) > 0 {
+			hygiene.CoAuthored++
+			for _, match := range matches {
+				coAuthors[strings.ToLower(match[1])] = true
+			}
+		}
+
+		subject := commitSubject(commit.Message)
+		if isMergeCommit(commit, subject) {
+			hygiene.Merges++
+			continue
+		}
+		hygiene.Analyzed++
+
+		if conventional, ok := ParseConventionalCommit(commit.Message); ok {
+			hygiene.Conventional++
+			byType[conventional.Type]++
+			if conventional.Breaking {
+				hygiene.Breaking++
+			}
+		} else if len(hygiene.NonConventional) < nonConventionalSamples {
+			hygiene.NonConventional = append(hygiene.NonConventional, subject)
+		}
+
+		length := utf8.RuneCountInString(subject)
+		lengths = append(lengths, length)
+		if length > subjectLengthLimit {
+			hygiene.LongSubjects++
+		}
+		for i, bucket := range subjectLengthBuckets {
+			if bucket.maxLength == 0 || length <= bucket.maxLength {
+				buckets[i]++
+				break
+			}
+		}
+
+		if !linksIssue(commit.Message) {
+			hygiene.Unlinked++
+		}
+	}
+	hygiene.CoAuthors = len(coAuthors)
+
+	for _, commitType := range ConventionalTypes {
+		if byType[commitType] > 0 {
+			hygiene.ByType = append(hygiene.ByType, &CommitTypeCount{Type: commitType, Count: byType[commitType]})
+		}
+	}
+	for i, bucket := range subjectLengthBuckets {
+		hygiene.SubjectLengths = append(hygiene.SubjectLengths, &SubjectLengthCount{Label: bucket.label, Count: buckets[i]})
+	}
+	if len(lengths) > 0 {
+		sort.Ints(lengths)
+		hygiene.MedianSubjectLength = lengths[len(lengths)/2]
+	}
+
+	hygiene.MergeRatio = ratio(hygiene.Merges, hygiene.Commits)
+	hygiene.ConventionalRatio = ratio(hygiene.Conventional, hygiene.Analyzed)
+	hygiene.VerifiedRatio = ratio(hygiene.Verified, hygiene.VerificationKnown)
+	hygiene.UnlinkedRatio = ratio(hygiene.Unlinked, hygiene.Analyzed)
+	return hygiene
+}
+
+// linksIssue verifica se a mensagem cita uma issue ou PR, desconsiderando os
+// números de PR acrescentados pelo GitHub
+func linksIssue(message string) bool {
+	return issueReferencePattern.MatchString(pullRequestNumberPattern.ReplaceAllString(message, ""))
+}
+
+// isMergeCommit identifica merges pelos pais ou, sem eles, pelo assunto gerado
+// pelo git e pelo GitHub
+func isMergeCommit(commit *extractor.CommitData, subject string) bool {
+	if commit.Parents > 0 {
+		return commit.Parents > 1
+	}
+	for _, prefix := range mergeSubjectPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ratio retorna a fração part/total ou 0 sem total
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// commitHistory retorna o histórico da análise das mensagens; dados extraídos
+// antes dele existir trazem apenas os commits recentes
+func commitHistory(data *extractor.RepositoryData) []*extractor.CommitData {
+	if len(data.CommitHistory) > 0 {
+		return data.CommitHistory
+	}
+	return data.RecentCommits
+}
+
+// commitHygieneBasis descreve as frações sobre o histórico de commits: exatas
+// quando ele cabe em extractor.CommitHistorySize e, acima disso, amostrais,
+// mas estáveis o bastante para confiança média
+func commitHygieneBasis(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+	n := len(commitHistory(d))
+	switch {
+	case n == 0:
+		return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+	case n < extractor.CommitHistorySize:
+		return &SignalBasis{Source: SourceAPI, SampleSize: n, Confidence: ConfidenceHigh}
+	}
+	return &SignalBasis{Source: SourceSample, SampleSize: n, Confidence: ConfidenceMedium}
+}
+
+// conventionalText descreve a aderência ao Conventional Commits
+func (h *CommitHygiene) conventionalText() string {
+	text := fmt.Sprintf("%d de %d (%.0f%%)", h.Conventional, h.Analyzed, h.ConventionalRatio*100)
+	if len(h.ByType) > 0 {
+		parts := make([]string, len(h.ByType))
+		for i, count := range h.ByType {
+			parts[i] = fmt.Sprintf("%s: %d", count.Type, count.Count)
+		}
+		text += " · " + strings.Join(parts, ", ")
+	}
+	if h.Breaking > 0 {
+		text += fmt.Sprintf(" · %d quebras de compatibilidade", h.Breaking)
+	}
+	return text
+}
+
+// subjectText descreve a distribuição do tamanho dos assuntos
+func (h *CommitHygiene) subjectText() string {
+	parts := make([]string, len(h.SubjectLengths))
+	for i, bucket := range h.SubjectLengths {
+		parts[i] = fmt.Sprintf("%s: %d", bucket.Label, bucket.Count)
+	}
+	return fmt.Sprintf("mediana de %d caracteres · %s · %d acima de %d",
+		h.MedianSubjectLength, strings.Join(parts, ", "), h.LongSubjects, subjectLengthLimit)
+}
+
+// signatureText descreve as assinaturas dos commits
+func (h *CommitHygiene) signatureText() string {
+	if h.VerificationKnown == 0 {
+		return "sem dados de verificação"
+	}
+	return fmt.Sprintf("%d assinados · %d verificados (%.0f%% de %d)",
+		h.Signed, h.Verified, h.VerifiedRatio*100, h.VerificationKnown)
+}
+
+func (h *CommitHygiene) sectionTitle() string { return "🧹 Higiene dos commits" }
+
+// sectionLines resume padrão das mensagens, assinaturas, co-autoria e
+// referências a issues
+func (h *CommitHygiene) sectionLines() [][2]string {
+	lines := [][2]string{
+		{"Commits analisados", fmt.Sprintf("%d · %d merges (%.0f%%)", h.Commits, h.Merges, h.MergeRatio*100)},
+	}
+	if h.Analyzed > 0 {
+		lines = append(lines,
+			[2]string{"Conventional Commits", h.conventionalText()},
+			[2]string{"Tamanho do assunto", h.subjectText()},
+			[2]string{"Sem issue vinculada", fmt.Sprintf("%d de %d (%.0f%%)", h.Unlinked, h.Analyzed, h.UnlinkedRatio*100)},
+		)
+	}
+	lines = append(lines,
+		[2]string{"Assinaturas", h.signatureText()},
+		[2]string{"Co-autoria", fmt.Sprintf("%d commits com Co-authored-by (%d co-autores)", h.CoAuthored, h.CoAuthors)},
+	)
+	if len(h.NonConventional) > 0 {
+		lines = append(lines, [2]string{"Exemplos fora do padrão", strings.Join(h.NonConventional, " · ")})
+	}
+	return lines
+}
+
+// htmlChart mostra os assuntos por faixa de tamanho
+func (h *CommitHygiene) htmlChart() string {
+	if h.Analyzed == 0 {
+		return ""
+	}
+
+	var items []chartItem
+	for _, bucket := range h.SubjectLengths {
+		items = append(items, chartItem{Label: bucket.Label, Value: float64(bucket.Count)})
+	}
+	return barChartSVG(items)
+}
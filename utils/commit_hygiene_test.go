@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"testing"
+
+	"github-octokit-poc/extractor"
+)
+
+func TestLinksIssue(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"fix: corrige o parser\n\nFixes #45", true},
+		{"#12 trata timeout", true},
+		{"feat: suporte a GH-42", true},
+		{"feat: suporte a gh-42", true},
+		{"docs: ver octo-org/octo.repo#3", true},
+		{"fix: ver https://github.com/o/r/issues/9", true},
+		{"fix: ver https://github.com/o/r/pull/10", true},
+		{"fix: regressão de (#12) no parser", true},
+		{"fix: corrige #45 (#123)", true},
+
+		// Números de PR acrescentados pelo GitHub não contam
+		{"fix: corrige o parser (#123)", false},
+		{"feat: exportação (#10)\n\n* feat: csv (#8)\n* fix: aspas (#9)", false},
+		{"Merge pull request #12 from octo/feature", false},
+		{"Merge pull request #12 from octo/feature\n\nCloses #7", true},
+
+		// "#" sem referência a issue
+		{"style: cor #fff no tema", false},
+		{"fix: escapa &#123; no HTML", false},
+		{"feat: suporte a C#7", false},
+		{"chore: atualiza dependências", false},
+	}
+
+	for _, tt := range tests {
+		if got := linksIssue(tt.message); got != tt.want {
+			t.Errorf("linksIssue(%q) = %v, esperado %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestIsMergeCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		parents int
+		subject string
+		want    bool
+	}{
+		{"dois pais", 2, "Integra a branch de release", true},
+		{"um pai com assunto de merge", 1, "Merge branch 'main'", false},
+		{"sem pais, merge de PR", 0, "Merge pull request #1 from a/b", true},
+		{"sem pais, merge de branch", 0, "Merge branch 'main' into feature", true},
+		{"sem pais, merge remoto", 0, "Merge remote-tracking branch 'origin/main'", true},
+		{"sem pais, commit comum", 0, "feat: merge de configurações", false},
+	}
+
+	for _, tt := range tests {
+		commit := &extractor.CommitData{Parents: tt.parents, Message: tt.subject}
+		if got := isMergeCommit(commit, tt.subject); got != tt.want {
+			t.Errorf("%s: isMergeCommit = %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeCommitHygiene(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		check    func(t *testing.T, h *CommitHygiene)
+	}{
+		{
+			name: "co-autores distintos por nome e email",
+			messages: []string{
+				"feat: a\n\nCo-authored-by: Ana <ana@example.com>\nco-authored-by: Bia <bia@example.com>",
+				"fix: b\n\nCO-AUTHORED-BY: ana <ANA@example.com>  ",
+				"docs: c\n\nEste texto cita Co-authored-by: no meio da linha",
+			},
+			check: func(t *testing.T, h *CommitHygiene) {
+				if h.CoAuthored != 2 || h.CoAuthors != 2 {
+					t.Errorf("CoAuthored/CoAuthors = %d/%d, esperado 2/2", h.CoAuthored, h.CoAuthors)
+				}
+			},
+		},
+		{
+			name: "merges ficam fora das métricas de mensagem",
+			messages: []string{
+				"Merge pull request #3 from a/b",
+				"feat(api)!: remove v1 (#2)",
+				"Atualiza README",
+			},
+			check: func(t *testing.T, h *CommitHygiene) {
+				if h.Merges != 1 || h.Analyzed != 2 {
+					t.Errorf("Merges/Analyzed = %d/%d, esperado 1/2", h.Merges, h.Analyzed)
+				}
+				if h.Conventional != 1 || h.Breaking != 1 || h.ConventionalRatio != 0.5 {
+					t.Errorf("Conventional/Breaking/ratio = %d/%d/%.2f, esperado 1/1/0.50", h.Conventional, h.Breaking, h.ConventionalRatio)
+				}
+				if h.Unlinked != 2 {
+					t.Errorf("Unlinked = %d, esperado 2", h.Unlinked)
+				}
+				if len(h.NonConventional) != 1 || h.NonConventional[0] != "Atualiza README" {
+					t.Errorf("NonConventional = %q, esperado [\"Atualiza README\"]", h.NonConventional)
+				}
+			},
+		},
+		{
+			name: "faixas de tamanho do assunto",
+			messages: []string{
+				"fix: curto",
+				"fix: " + repeatRune('a', 60),
+				"fix: " + repeatRune('é', 90),
+				"fix: " + repeatRune('b', 120) + "\n\ncorpo longo não conta",
+			},
+			check: func(t *testing.T, h *CommitHygiene) {
+				want := []int{1, 1, 1, 1}
+				for i, bucket := range h.SubjectLengths {
+					if bucket.Count != want[i] {
+						t.Errorf("faixa %q = %d, esperado %d", bucket.Label, bucket.Count, want[i])
+					}
+				}
+				if h.LongSubjects != 2 {
+					t.Errorf("LongSubjects = %d, esperado 2", h.LongSubjects)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &extractor.RepositoryData{}
+			for _, message := range tt.messages {
+				data.RecentCommits = append(data.RecentCommits, &extractor.CommitData{Message: message})
+			}
+			tt.check(t, AnalyzeCommitHygiene(data))
+		})
+	}
+
+	if AnalyzeCommitHygiene(&extractor.RepositoryData{}) != nil {
+		t.Error("sem commits a análise deveria ser nil")
+	}
+
+	// O histórico, quando extraído, substitui os commits recentes
+	history := &extractor.RepositoryData{
+		RecentCommits: []*extractor.CommitData{{Message: "fix: a"}},
+		CommitHistory: []*extractor.CommitData{{Message: "fix: a"}, {Message: "trata b"}},
+	}
+	if h := AnalyzeCommitHygiene(history); h.Commits != 2 || h.Conventional != 1 {
+		t.Errorf("Commits = %d, Conventional = %d, esperado 2 e 1", h.Commits, h.Conventional)
+	}
+}
+
+// repeatRune repete o caractere n vezes
+func repeatRune(r rune, n int) string {
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = r
+	}
+	return string(runes)
+}
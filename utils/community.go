@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github-octokit-poc/extractor"
+)
+
+// communityLabels traduz os arquivos de comunidade para exibição
+var communityLabels = map[string]string{
+	extractor.CommunityReadme:              "README",
+	extractor.CommunityLicense:             "Licença (LICENSE)",
+	extractor.CommunityContributing:        "Guia de contribuição (CONTRIBUTING)",
+	extractor.CommunityCodeOfConduct:       "Código de conduta (CODE_OF_CONDUCT)",
+	extractor.CommunitySecurity:            "Política de segurança (SECURITY)",
+	extractor.CommunityIssueTemplate:       "Templates de issue",
+	extractor.CommunityPullRequestTemplate: "Template de pull request",
+}
+
+// CommunityStandards resume os arquivos de comunidade do repositório
+type CommunityStandards struct {
+	HealthPercentage int              `json:"health_percentage"`
+	ProfileAvailable bool             `json:"profile_available"`
+	Present          int              `json:"present"`
+	Total            int              `json:"total"`
+	Items            []*CommunityItem `json:"items"`
+	Missing          []string         `json:"missing"`
+}
+
+// CommunityItem indica a situação de um arquivo de comunidade
+type CommunityItem struct {
+	Key     string `json:"key"`
+	Label   string `json:"label"`
+	Present bool   `json:"present"`
+	Path    string `json:"path,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Source  string `json:"source,omitempty"`
+}
+
+// AnalyzeCommunity lista os arquivos de comunidade encontrados e os que
+// faltam; retorna nil quando a verificação não foi feita na extração
+func AnalyzeCommunity(data *extractor.RepositoryData) *CommunityStandards {
+	community := data.Community
+	if community == nil {
+		return nil
+	}
+
+	standards := &CommunityStandards{
+		HealthPercentage: community.HealthPercentage,
+		ProfileAvailable: community.ProfileAvailable,
+		Total:            len(extractor.CommunityFileOrder),
+	}
+	for _, key := range extractor.CommunityFileOrder {
+		item := &CommunityItem{Key: key, Label: communityLabels[key]}
+		if file := community.Files[key]; file != nil {
+			item.Present = file.Present
+			item.Path = file.Path
+			item.URL = file.URL
+			item.Source = file.Source
+		}
+		// A licença detectada pelo GitHub também conta, mesmo fora da raiz
+		if key == extractor.CommunityLicense && !item.Present && data.BasicInfo != nil && data.BasicInfo.License != "" {
+			item.Present = true
+			item.Source = "license"
+		}
+
+		if item.Present {
+			standards.Present++
+		} else {
+			standards.Missing = append(standards.Missing, item.Label)
+		}
+		standards.Items = append(standards.Items, item)
+	}
+
+	return standards
+}
+
+// location descreve onde o arquivo foi encontrado
+func (i *CommunityItem) location() string {
+	switch {
+	case !i.Present:
+		return "não encontrado"
+	case i.Path != "":
+		return i.Path
+	case i.Source == extractor.CommunitySourceProfile:
+		return "padrão da organização (community profile)"
+	default:
+		return "detectado pelo GitHub"
+	}
+}
+
+// summary resume o resultado em uma linha
+func (c *CommunityStandards) summary() string {
+	text := fmt.Sprintf("%d de %d itens presentes", c.Present, c.Total)
+	if c.ProfileAvailable {
+		text += fmt.Sprintf(" · community profile: %d%%", c.HealthPercentage)
+	}
+	return text
+}
+
+// missingText lista os itens ausentes
+func (c *CommunityStandards) missingText() string {
+	return strings.Join(c.Missing, ", ")
+}
+
+func (c *CommunityStandards) sectionTitle() string { return "🤝 Padrões de comunidade" }
+
+// sectionLines resume os itens presentes e os que faltam
+func (c *CommunityStandards) sectionLines() [][2]string {
+	lines := [][2]string{{"Resumo", c.summary()}}
+	if len(c.Missing) > 0 {
+		lines = append(lines, [2]string{"⚠️ Faltando", c.missingText()})
+	}
+	return lines
+}
+
+// mark retorna ✅ ou ❌ conforme o item foi encontrado
+func (i *CommunityItem) mark() string {
+	if i.Present {
+		return "✅"
+	}
+	return "❌"
+}
+
+// textDetails lista a situação de cada item
+func (c *CommunityStandards) textDetails() []string {
+	details := make([]string, len(c.Items))
+	for i, item := range c.Items {
+		details[i] = fmt.Sprintf("%s %s: %s", item.mark(), item.Label, item.location())
+	}
+	return details
+}
+
+// markdownDetails lista a situação de cada item em tabela, com link para os
+// arquivos encontrados
+func (c *CommunityStandards) markdownDetails() string {
+	var md strings.Builder
+	md.WriteString("| Item | Situação | Local |\n|---|:---:|---|\n")
+	for _, item := range c.Items {
+		location := escapeMarkdownCell(item.location())
+		if item.Present && item.URL != "" {
+			location = fmt.Sprintf("[%s](%s)", location, item.URL)
+		}
+		md.WriteString(fmt.Sprintf("| %s | %s | %s |\n", item.Label, item.mark(), location))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
+
+// htmlDetails lista a situação de cada item em tabela, com link para os
+// arquivos encontrados
+func (c *CommunityStandards) htmlDetails() string {
+	var page strings.Builder
+	page.WriteString("<table><tr><th>Item</th><th>Situação</th><th>Local</th></tr>")
+	for _, item := range c.Items {
+		location := html.EscapeString(item.location())
+		if item.Present && item.URL != "" {
+			location = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(item.URL), location)
+		}
+		page.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td></tr>",
+			html.EscapeString(item.Label), item.mark(), location))
+	}
+	page.WriteString("</table>")
+	return page.String()
+}
@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// ComparisonEntry representa as métricas de um repositório na comparação
+type ComparisonEntry struct {
+	FullName            string   `json:"full_name"`
+	URL                 string   `json:"url"`
+	Stars               int      `json:"stars"`
+	Forks               int      `json:"forks"`
+	Contributors        int      `json:"contributors"`
+	CoreTeamSize        int      `json:"core_team_size"`
+	ReleaseCadenceDays  float64  `json:"release_cadence_days"`
+	IssueResponsiveness float64  `json:"issue_responsiveness"`
+	HealthScore         float64  `json:"health_score"`
+	HealthStatus        string   `json:"health_status"`
+	License             string   `json:"license"`
+	Languages           []string `json:"languages"`
+}
+
+// Comparison representa a matriz de comparação entre repositórios
+type Comparison struct {
+	Entries     []*ComparisonEntry `json:"entries"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// comparisonMetric descreve uma linha da matriz de comparação
+type comparisonMetric struct {
+	label string
+	value func(e *ComparisonEntry) string
+	// score retorna o valor numérico usado para destacar o melhor repositório;
+	// nil indica uma métrica apenas informativa
+	score func(e *ComparisonEntry) float64
+}
+
+// comparisonMetrics define as linhas da matriz na ordem de exibição
+var comparisonMetrics = []comparisonMetric{
+	{
+		label: "⭐ Stars",
+		value: func(e *ComparisonEntry) string { return formatNumber(e.Stars) },
+		score: func(e *ComparisonEntry) float64 { return float64(e.Stars) },
+	},
+	{
+		label: "🍴 Forks",
+		value: func(e *ComparisonEntry) string { return formatNumber(e.Forks) },
+		score: func(e *ComparisonEntry) float64 { return float64(e.Forks) },
+	},
+	{
+		label: "👥 Colaboradores",
+		value: func(e *ComparisonEntry) string { return fmt.Sprintf("%d", e.Contributors) },
+		score: func(e *ComparisonEntry) float64 { return float64(e.Contributors) },
+	},
+	{
+		label: "🛠️  Time principal",
+		value: func(e *ComparisonEntry) string { return fmt.Sprintf("%d", e.CoreTeamSize) },
+		score: func(e *ComparisonEntry) float64 { return float64(e.CoreTeamSize) },
+	},
+	{
+		label: "🚀 Cadência de releases",
+		value: func(e *ComparisonEntry) string {
+			if e.ReleaseCadenceDays == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.0f dias", e.ReleaseCadenceDays)
+		},
+		score: func(e *ComparisonEntry) float64 {
+			if e.ReleaseCadenceDays == 0 {
+				return -1e9
+			}
+			return -e.ReleaseCadenceDays
+		},
+	},
+	{
+		label: "💬 Issues respondidas",
+		value: func(e *ComparisonEntry) string { return fmt.Sprintf("%.0f%%", e.IssueResponsiveness*100) },
+		score: func(e *ComparisonEntry) float64 { return e.IssueResponsiveness },
+	},
+	{
+		label: "🏥 Score de saúde",
+		value: func(e *ComparisonEntry) string { return fmt.Sprintf("%.0f (%s)", e.HealthScore, e.HealthStatus) },
+		score: func(e *ComparisonEntry) float64 { return e.HealthScore },
+	},
+	{
+		label: "📄 Licença",
+		value: func(e *ComparisonEntry) string { return valueOrDash(e.License) },
+	},
+	{
+		label: "💻 Linguagens",
+		value: func(e *ComparisonEntry) string { return valueOrDash(strings.Join(e.Languages, ", ")) },
+	},
+}
+
+// CompareRepositories monta a matriz de comparação entre repositórios
+func CompareRepositories(datasets []*extractor.RepositoryData, analyzer Analyzer) *Comparison {
+	comparison := &Comparison{GeneratedAt: time.Now()}
+
+	for _, data := range datasets {
+		analysis := analyzer.Analyze(data)
+		contributors := analysis.Contributors
+		health := analysis.Health
+
+		cadence := 0.0
+		if releases := analysis.Releases; releases != nil {
+			cadence = releases.MeanIntervalDays
+		}
+
+		var languages []string
+		for i, lang := range analysis.Languages {
+			if i >= 3 { // Top 3
+				break
+			}
+			languages = append(languages, fmt.Sprintf("%s %.0f%%", lang.Name, lang.Percentage))
+		}
+
+		comparison.Entries = append(comparison.Entries, &ComparisonEntry{
+			FullName:            data.BasicInfo.FullName,
+			URL:                 data.BasicInfo.URL,
+			Stars:               data.Statistics.Stars,
+			Forks:               data.Statistics.Forks,
+			Contributors:        contributors.TotalContributors,
+			CoreTeamSize:        contributors.CoreTeamSize,
+			ReleaseCadenceDays:  cadence,
+			IssueResponsiveness: issueResponsiveness(data.RecentIssues),
+			HealthScore:         health.HealthScore,
+			HealthStatus:        health.MaintenanceStatus,
+			License:             data.BasicInfo.License,
+			Languages:           languages,
+		})
+	}
+
+	return comparison
+}
+
+// RenderTable renderiza a matriz como tabela para o terminal
+func (c *Comparison) RenderTable() string {
+	var sb strings.Builder
+
+	sb.WriteString("⚖️  COMPARAÇÃO DE REPOSITÓRIOS\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	header := []string{"MÉTRICA"}
+	for _, entry := range c.Entries {
+		header = append(header, entry.FullName)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, metric := range comparisonMetrics {
+		best := c.bestIndexes(metric)
+		row := []string{metric.label}
+		for i, entry := range c.Entries {
+			value := metric.value(entry)
+			if best[i] {
+				value += " 🏆"
+			}
+			row = append(row, value)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("🏆 = melhor valor entre os repositórios comparados\n")
+
+	return sb.String()
+}
+
+// RenderMarkdown renderiza a matriz como tabela Markdown
+func (c *Comparison) RenderMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("# ⚖️ Comparação de repositórios\n\n")
+
+	sb.WriteString("| Métrica |")
+	for _, entry := range c.Entries {
+		sb.WriteString(fmt.Sprintf(" [%s](%s) |", entry.FullName, entry.URL))
+	}
+	sb.WriteString("\n|---|")
+	for range c.Entries {
+		sb.WriteString("---|")
+	}
+	sb.WriteString("\n")
+
+	for _, metric := range comparisonMetrics {
+		best := c.bestIndexes(metric)
+		sb.WriteString(fmt.Sprintf("| %s |", metric.label))
+		for i, entry := range c.Entries {
+			value := escapeMarkdownCell(metric.value(entry))
+			if best[i] {
+				value = "**" + value + "**"
+			}
+			sb.WriteString(" " + value + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n_Valores em negrito são os melhores da comparação. Gerado em %s._\n",
+		c.GeneratedAt.Format("02/01/2006 15:04:05")))
+
+	return sb.String()
+}
+
+// RenderHTML renderiza a matriz como página HTML autocontida
+func (c *Comparison) RenderHTML() string {
+	var sb strings.Builder
+
+	sb.WriteString(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>Comparação de repositórios</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #24292f; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d0d7de; padding: 0.5rem 0.75rem; text-align: left; }
+thead th { background: #f6f8fa; }
+td.best { background: #dafbe1; font-weight: 600; }
+a { color: #0969da; text-decoration: none; }
+footer { margin-top: 1rem; color: #57606a; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+<h1>⚖️ Comparação de repositórios</h1>
+<table>
+<thead><tr><th>Métrica</th>`)
+
+	for _, entry := range c.Entries {
+		sb.WriteString(fmt.Sprintf(`<th><a href="%s">%s</a></th>`,
+			html.EscapeString(entry.URL), html.EscapeString(entry.FullName)))
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+
+	for _, metric := range comparisonMetrics {
+		best := c.bestIndexes(metric)
+		sb.WriteString(fmt.Sprintf("<tr><th>%s</th>", html.EscapeString(metric.label)))
+		for i, entry := range c.Entries {
+			class := ""
+			if best[i] {
+				class = ` class="best"`
+			}
+			sb.WriteString(fmt.Sprintf("<td%s>%s</td>", class, html.EscapeString(metric.value(entry))))
+		}
+		sb.WriteString("</tr>\n")
+	}
+
+	sb.WriteString("</tbody>\n</table>\n")
+	sb.WriteString(fmt.Sprintf("<footer>Células destacadas indicam o melhor valor. Gerado em %s.</footer>\n",
+		c.GeneratedAt.Format("02/01/2006 15:04:05")))
+	sb.WriteString("</body>\n</html>\n")
+
+	return sb.String()
+}
+
+// bestIndexes identifica quais repositórios têm o melhor valor na métrica
+func (c *Comparison) bestIndexes(metric comparisonMetric) map[int]bool {
+	best := make(map[int]bool)
+	if metric.score == nil || len(c.Entries) < 2 {
+		return best
+	}
+
+	scores := make([]float64, len(c.Entries))
+	for i, entry := range c.Entries {
+		scores[i] = metric.score(entry)
+	}
+
+	max := scores[0]
+	for _, score := range scores[1:] {
+		if score > max {
+			max = score
+		}
+	}
+
+	// Sem diferença entre os repositórios não há destaque
+	allEqual := true
+	for i, score := range scores {
+		if score == max {
+			best[i] = true
+		} else {
+			allEqual = false
+		}
+	}
+	if allEqual {
+		return map[int]bool{}
+	}
+
+	return best
+}
+
+// issueResponsiveness calcula a fração de issues da amostra com ao menos um comentário
+func issueResponsiveness(issues []*extractor.IssueData) float64 {
+	if len(issues) == 0 {
+		return 0
+	}
+
+	answered := 0
+	for _, issue := range issues {
+		if issue.Comments > 0 {
+			answered++
+		}
+	}
+	return float64(answered) / float64(len(issues))
+}
+
+// escapeMarkdownCell escapa caracteres que quebram células de tabelas Markdown
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// valueOrDash retorna "-" para valores vazios
+func valueOrDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
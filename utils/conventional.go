@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// conventionalPattern reconhece o cabeçalho de um Conventional Commit:
+// tipo, escopo opcional, "!" opcional de quebra e descrição
+var conventionalPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: (\S.*)$`)
+
+// breakingFooterPattern reconhece o rodapé de quebra de compatibilidade
+var breakingFooterPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// ConventionalTypes são os tipos reconhecidos (Angular/commitlint), na ordem
+// de exibição do changelog
+var ConventionalTypes = []string{"feat", "fix", "perf", "refactor", "revert", "docs", "style", "test", "build", "ci", "chore"}
+
+// ConventionalCommit é o cabeçalho interpretado de um Conventional Commit
+type ConventionalCommit struct {
+	Type        string `json:"type"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking"`
+}
+
+// ParseConventionalCommit interpreta a mensagem (ou título de PR) como
+// Conventional Commit; tipos fora de ConventionalTypes não são aceitos, para
+// que prefixos como "WIP:" ou "Merge:" não passem por tipos
+func ParseConventionalCommit(message string) (*ConventionalCommit, bool) {
+	subject, _, _ := strings.Cut(message, "\n")
+	match := conventionalPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return nil, false
+	}
+
+	commitType := strings.ToLower(match[1])
+	if !isConventionalType(commitType) {
+		return nil, false
+	}
+	return &ConventionalCommit{
+		Type:        commitType,
+		Scope:       strings.TrimSpace(match[2]),
+		Description: match[4],
+		Breaking:    match[3] == "!" || breakingFooterPattern.MatchString(message),
+	}, true
+}
+
+// isConventionalType verifica se o tipo está em ConventionalTypes
+func isConventionalType(commitType string) bool {
+	for _, known := range ConventionalTypes {
+		if known == commitType {
+			return true
+		}
+	}
+	return false
+}
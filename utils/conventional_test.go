@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		message string
+		want    *ConventionalCommit
+	}{
+		{"feat: adiciona exportação CSV", &ConventionalCommit{Type: "feat", Description: "adiciona exportação CSV"}},
+		{"fix(parser): trata linhas vazias", &ConventionalCommit{Type: "fix", Scope: "parser", Description: "trata linhas vazias"}},
+		{"Fix( api ): normaliza tipo", &ConventionalCommit{Type: "fix", Scope: "api", Description: "normaliza tipo"}},
+		{"feat(api)!: remove endpoint v1", &ConventionalCommit{Type: "feat", Scope: "api", Description: "remove endpoint v1", Breaking: true}},
+		{"refactor!: troca o cliente HTTP", &ConventionalCommit{Type: "refactor", Description: "troca o cliente HTTP", Breaking: true}},
+		{"chore: atualiza dependências\n\nBREAKING CHANGE: exige Go 1.21", &ConventionalCommit{Type: "chore", Description: "atualiza dependências", Breaking: true}},
+		{"perf: cache de tags\n\nBREAKING-CHANGE: muda a chave", &ConventionalCommit{Type: "perf", Description: "cache de tags", Breaking: true}},
+		{"docs: menciona BREAKING CHANGE: no guia", &ConventionalCommit{Type: "docs", Description: "menciona BREAKING CHANGE: no guia"}},
+		{"  ci: roda testes no PR  \n\ncorpo", &ConventionalCommit{Type: "ci", Description: "roda testes no PR"}},
+
+		// Não são Conventional Commits
+		{"WIP: ainda não terminei", nil},
+		{"Merge: branch main", nil},
+		{"feat:sem espaço", nil},
+		{"feat:  ", nil},
+		{"feat(a(b)): escopo aninhado", nil},
+		{"feature: tipo desconhecido", nil},
+		{"Adiciona exportação CSV", nil},
+		{"corpo antes\nfeat: no corpo", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseConventionalCommit(tt.message)
+		if tt.want == nil {
+			if ok {
+				t.Errorf("ParseConventionalCommit(%q) = %+v, esperado não reconhecer", tt.message, got)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("ParseConventionalCommit(%q) não reconheceu, esperado %+v", tt.message, tt.want)
+			continue
+		}
+		if *got != *tt.want {
+			t.Errorf("ParseConventionalCommit(%q) = %+v, esperado %+v", tt.message, got, tt.want)
+		}
+	}
+}
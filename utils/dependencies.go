@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github-octokit-poc/extractor"
+)
+
+// DependencySummary resume as dependências extraídas do SBOM e dos manifestos
+type DependencySummary struct {
+	SBOMAvailable bool                  `json:"sbom_available"`
+	Manifests     []*extractor.Manifest `json:"manifests"`
+	Total         int                   `json:"total"`
+	Direct        int                   `json:"direct"`
+	Indirect      int                   `json:"indirect"`
+	Unknown       int                   `json:"unknown"`
+	Development   int                   `json:"development"`
+	// Unpinned conta dependências diretas declaradas com faixa de versão
+	Unpinned   int               `json:"unpinned"`
+	Ecosystems []*EcosystemCount `json:"ecosystems"`
+}
+
+// EcosystemCount conta as dependências de um ecossistema
+type EcosystemCount struct {
+	Ecosystem string `json:"ecosystem"`
+	Total     int    `json:"total"`
+	Direct    int    `json:"direct"`
+}
+
+// AnalyzeDependencies conta dependências por relação e ecossistema; retorna
+// nil quando nem o SBOM nem manifestos estavam disponíveis
+func AnalyzeDependencies(data *extractor.RepositoryData) *DependencySummary {
+	deps := data.Dependencies
+	if deps == nil {
+		return nil
+	}
+
+	summary := &DependencySummary{
+		SBOMAvailable: deps.SBOMAvailable,
+		Manifests:     deps.Manifests,
+		Total:         len(deps.Dependencies),
+	}
+	ecosystems := make(map[string]*EcosystemCount)
+	for _, dep := range deps.Dependencies {
+		count, ok := ecosystems[dep.Ecosystem]
+		if !ok {
+			count = &EcosystemCount{Ecosystem: dep.Ecosystem}
+			ecosystems[dep.Ecosystem] = count
+		}
+		count.Total++
+
+		switch dep.Relationship {
+		case extractor.DependencyDirect:
+			summary.Direct++
+			count.Direct++
+			// A versão do manifesto só fica se o SBOM não trouxe a resolvida
+			if !extractor.IsExactVersion(dep.Version) && len(dep.Sources) == 1 {
+				summary.Unpinned++
+			}
+		case extractor.DependencyIndirect:
+			summary.Indirect++
+		default:
+			summary.Unknown++
+		}
+		if dep.Scope == extractor.ScopeDevelopment {
+			summary.Development++
+		}
+	}
+
+	for _, count := range ecosystems {
+		summary.Ecosystems = append(summary.Ecosystems, count)
+	}
+	sort.Slice(summary.Ecosystems, func(i, j int) bool {
+		if summary.Ecosystems[i].Total != summary.Ecosystems[j].Total {
+			return summary.Ecosystems[i].Total > summary.Ecosystems[j].Total
+		}
+		return summary.Ecosystems[i].Ecosystem < summary.Ecosystems[j].Ecosystem
+	})
+
+	return summary
+}
+
+// summary resume as dependências em uma linha
+func (d *DependencySummary) summary() string {
+	text := fmt.Sprintf("%d dependências · %d diretas · %d indiretas", d.Total, d.Direct, d.Indirect)
+	if d.Unknown > 0 {
+		text += fmt.Sprintf(" · %d sem relação conhecida", d.Unknown)
+	}
+	if d.Development > 0 {
+		text += fmt.Sprintf(" · %d de desenvolvimento", d.Development)
+	}
+	return text
+}
+
+// sourcesText descreve de onde as dependências vieram
+func (d *DependencySummary) sourcesText() string {
+	sbom := "SBOM do dependency graph indisponível"
+	if d.SBOMAvailable {
+		sbom = "SBOM do dependency graph"
+	}
+	if len(d.Manifests) == 0 {
+		return sbom + " · nenhum manifesto encontrado"
+	}
+	paths := make([]string, len(d.Manifests))
+	for i, manifest := range d.Manifests {
+		paths[i] = manifest.Path
+		if manifest.Error != "" {
+			paths[i] += " (erro)"
+		}
+	}
+	return fmt.Sprintf("%s · manifestos: %s", sbom, strings.Join(paths, ", "))
+}
+
+// ecosystemsText formata a contagem por ecossistema
+func (d *DependencySummary) ecosystemsText() string {
+	parts := make([]string, len(d.Ecosystems))
+	for i, count := range d.Ecosystems {
+		parts[i] = fmt.Sprintf("%s: %d (%d diretas)", count.Ecosystem, count.Total, count.Direct)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (d *DependencySummary) sectionTitle() string { return "📦 Dependências" }
+
+// sectionLines resume as dependências por ecossistema, relação e fonte
+func (d *DependencySummary) sectionLines() [][2]string {
+	lines := [][2]string{
+		{"Resumo", d.summary()},
+		{"Fontes", d.sourcesText()},
+	}
+	if len(d.Ecosystems) > 0 {
+		lines = append(lines, [2]string{"Ecossistemas", d.ecosystemsText()})
+	}
+	if d.Unpinned > 0 {
+		lines = append(lines, [2]string{"Diretas sem versão fixa", fmt.Sprintf("%d", d.Unpinned)})
+	}
+	return lines
+}
+
+// htmlChart mostra a divisão das dependências por ecossistema
+func (d *DependencySummary) htmlChart() string {
+	if len(d.Ecosystems) == 0 {
+		return ""
+	}
+
+	var items []chartItem
+	for _, count := range d.Ecosystems {
+		items = append(items, chartItem{Label: count.Ecosystem, Value: float64(count.Total)})
+	}
+	return pieChartSVG(items)
+}
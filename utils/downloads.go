@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// DownloadTrendWindow é a distância mínima preferida entre a extração e o
+// snapshot usado como base da tendência de downloads
+const DownloadTrendWindow = 7 * 24 * time.Hour
+
+// Padrões especiais de assets, além das plataformas (ex: "linux-amd64")
+const (
+	AssetPatternChecksums = "checksums/assinaturas"
+	AssetPatternOther     = "outros"
+)
+
+// assetOSTokens normaliza os nomes de sistema operacional usados em assets
+var assetOSTokens = map[string]string{
+	"linux": "linux", "darwin": "darwin", "macos": "darwin", "osx": "darwin", "mac": "darwin", "apple": "darwin",
+	"windows": "windows", "win": "windows", "win64": "windows", "win32": "windows",
+	"freebsd": "freebsd", "openbsd": "openbsd", "netbsd": "netbsd", "android": "android",
+}
+
+// assetArchTokens normaliza os nomes de arquitetura usados em assets
+var assetArchTokens = map[string]string{
+	"amd64": "amd64", "x64": "amd64", "64bit": "amd64", "win64": "amd64",
+	"arm64": "arm64", "aarch64": "arm64",
+	"386": "386", "i386": "386", "i686": "386", "x86": "386", "32bit": "386", "win32": "386",
+	"arm": "arm", "armv6": "arm", "armv7": "arm", "armv7l": "arm", "armhf": "arm",
+	"universal": "universal", "ppc64le": "ppc64le", "s390x": "s390x", "riscv64": "riscv64",
+}
+
+// assetPackageOS associa extensões de pacotes ao sistema operacional
+var assetPackageOS = map[string]string{
+	".deb": "linux", ".rpm": "linux", ".appimage": "linux",
+	".dmg": "darwin", ".pkg": "darwin",
+	".msi": "windows", ".exe": "windows",
+}
+
+// assetChecksumSuffixes identificam checksums, assinaturas e atestados
+var assetChecksumSuffixes = []string{".sha256", ".sha512", ".sha256sum", ".md5", ".sig", ".asc", ".pem", ".sbom", ".intoto.jsonl"}
+
+// assetTokenPattern separa o nome do asset em palavras
+var assetTokenPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+// DownloadAnalysis resume os downloads dos assets dos releases
+type DownloadAnalysis struct {
+	Total    int                 `json:"total"`
+	Assets   int                 `json:"assets"`
+	Releases []*ReleaseDownloads `json:"releases"`
+	Patterns []*PatternDownloads `json:"patterns"`
+	Trend    *DownloadTrend      `json:"trend,omitempty"`
+}
+
+// ReleaseDownloads soma os downloads dos assets de um release
+type ReleaseDownloads struct {
+	Tag         string    `json:"tag"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      int       `json:"assets"`
+	Downloads   int       `json:"downloads"`
+}
+
+// PatternDownloads soma os downloads dos assets de uma plataforma ou tipo
+type PatternDownloads struct {
+	Pattern   string  `json:"pattern"`
+	Assets    int     `json:"assets"`
+	Downloads int     `json:"downloads"`
+	Share     float64 `json:"share"`
+}
+
+// DownloadTrend compara os downloads com um snapshot anterior
+type DownloadTrend struct {
+	Since     time.Time `json:"since"`
+	Days      float64   `json:"days"`
+	Downloads int       `json:"downloads"`
+	PerDay    float64   `json:"per_day"`
+	// Patterns são os downloads no período por padrão, do maior para o menor
+	Patterns []*PatternDownloads `json:"patterns"`
+}
+
+// AnalyzeDownloads soma os downloads por release e por padrão de asset e,
+// com um snapshot base (baseline, pode ser nil), calcula a tendência desde
+// ele; retorna nil quando nenhum release tem assets
+func AnalyzeDownloads(data, baseline *extractor.RepositoryData) *DownloadAnalysis {
+	analysis := &DownloadAnalysis{}
+	patterns := make(map[string]*PatternDownloads)
+	for _, release := range data.Releases {
+		if len(release.Assets) == 0 {
+			continue
+		}
+		releaseDownloads := &ReleaseDownloads{Tag: release.TagName, PublishedAt: release.PublishedAt, Assets: len(release.Assets)}
+		for _, asset := range release.Assets {
+			releaseDownloads.Downloads += asset.DownloadCount
+
+			pattern := AssetPattern(asset.Name)
+			count, ok := patterns[pattern]
+			if !ok {
+				count = &PatternDownloads{Pattern: pattern}
+				patterns[pattern] = count
+			}
+			count.Assets++
+			count.Downloads += asset.DownloadCount
+		}
+		analysis.Assets += releaseDownloads.Assets
+		analysis.Total += releaseDownloads.Downloads
+		analysis.Releases = append(analysis.Releases, releaseDownloads)
+	}
+	if analysis.Assets == 0 {
+		return nil
+	}
+
+	analysis.Patterns = sortedPatterns(patterns, analysis.Total)
+	analysis.Trend = downloadTrend(data, baseline)
+	return analysis
+}
+
+// AssetPattern classifica o asset pela plataforma do nome (ex:
+// "tool_1.2.0_Linux_x86_64.tar.gz" -> "linux-amd64"), pela extensão de pacotes
+// (".deb", ".dmg", ".msi") ou como checksum/assinatura
+func AssetPattern(name string) string {
+	lower := strings.ToLower(name)
+	for _, suffix := range assetChecksumSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return AssetPatternChecksums
+		}
+	}
+	if strings.Contains(lower, "checksums") || strings.Contains(lower, "sha256sums") {
+		return AssetPatternChecksums
+	}
+
+	// x86_64 e x86-64 seriam separados em "x86" (32 bits) e "64"
+	lower = strings.NewReplacer("x86_64", "amd64", "x86-64", "amd64").Replace(lower)
+
+	var system, arch string
+	for _, token := range assetTokenPattern.FindAllString(lower, -1) {
+		if value, ok := assetOSTokens[token]; ok && system == "" {
+			system = value
+		}
+		if value, ok := assetArchTokens[token]; ok && arch == "" {
+			arch = value
+		}
+	}
+	if system == "" {
+		system = assetPackageOS[path.Ext(lower)]
+	}
+
+	switch {
+	case system != "" && arch != "":
+		return system + "-" + arch
+	case system != "":
+		return system
+	case arch != "":
+		return arch
+	}
+	return AssetPatternOther
+}
+
+// sortedPatterns ordena os padrões por downloads e calcula a participação
+func sortedPatterns(patterns map[string]*PatternDownloads, total int) []*PatternDownloads {
+	var sorted []*PatternDownloads
+	for _, count := range patterns {
+		if total > 0 {
+			count.Share = float64(count.Downloads) / float64(total) * 100
+		}
+		sorted = append(sorted, count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Downloads != sorted[j].Downloads {
+			return sorted[i].Downloads > sorted[j].Downloads
+		}
+		return sorted[i].Pattern < sorted[j].Pattern
+	})
+	return sorted
+}
+
+// downloadTrend compara os downloads atuais com os do snapshot base. Assets
+// que sumiram do snapshot atual não entram na conta.
+func downloadTrend(data, previous *extractor.RepositoryData) *DownloadTrend {
+	meta := data.ExtractionMeta
+	if previous == nil || previous.ExtractionMeta == nil || meta == nil || meta.ExtractedAt.IsZero() {
+		return nil
+	}
+
+	before := make(map[string]int)
+	for _, release := range previous.Releases {
+		for _, asset := range release.Assets {
+			before[release.TagName+"/"+asset.Name] = asset.DownloadCount
+		}
+	}
+
+	trend := &DownloadTrend{
+		Since: previous.ExtractionMeta.ExtractedAt,
+		Days:  meta.ExtractedAt.Sub(previous.ExtractionMeta.ExtractedAt).Hours() / 24,
+	}
+	patterns := make(map[string]*PatternDownloads)
+	for _, release := range data.Releases {
+		for _, asset := range release.Assets {
+			delta := asset.DownloadCount - before[release.TagName+"/"+asset.Name]
+			if delta <= 0 {
+				continue
+			}
+			trend.Downloads += delta
+
+			pattern := AssetPattern(asset.Name)
+			count, ok := patterns[pattern]
+			if !ok {
+				count = &PatternDownloads{Pattern: pattern}
+				patterns[pattern] = count
+			}
+			count.Assets++
+			count.Downloads += delta
+		}
+	}
+	if trend.Days > 0 {
+		trend.PerDay = float64(trend.Downloads) / trend.Days
+	}
+	trend.Patterns = sortedPatterns(patterns, trend.Downloads)
+	return trend
+}
+
+// patternsText formata os padrões com downloads e participação
+func patternsText(patterns []*PatternDownloads, limit int) string {
+	var parts []string
+	for i, count := range patterns {
+		if i >= limit {
+			parts = append(parts, fmt.Sprintf("+%d padrões", len(patterns)-limit))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s (%.0f%%)", count.Pattern, formatNumber(count.Downloads), count.Share))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// trendText descreve os downloads desde o snapshot base
+func (t *DownloadTrend) trendText() string {
+	text := fmt.Sprintf("+%s desde %s (%.1f dias) · %.1f por dia",
+		formatNumber(t.Downloads), t.Since.Format("02/01/2006"), t.Days, t.PerDay)
+	if len(t.Patterns) > 0 {
+		text += " · " + patternsText(t.Patterns, 3)
+	}
+	return text
+}
+
+func (d *DownloadAnalysis) sectionTitle() string { return "⬇️ Downloads" }
+
+// sectionLines resume os downloads por padrão de asset e a tendência
+func (d *DownloadAnalysis) sectionLines() [][2]string {
+	lines := [][2]string{
+		{"Total", fmt.Sprintf("%s downloads em %d assets de %d releases", formatNumber(d.Total), d.Assets, len(d.Releases))},
+		{"Por padrão", patternsText(d.Patterns, 6)},
+	}
+	if d.Trend != nil {
+		lines = append(lines, [2]string{"Tendência", d.Trend.trendText()})
+	}
+	return lines
+}
+
+// htmlChart mostra a divisão dos downloads por padrão de asset
+func (d *DownloadAnalysis) htmlChart() string {
+	if d.Total == 0 {
+		return ""
+	}
+
+	var items []chartItem
+	for _, count := range d.Patterns {
+		items = append(items, chartItem{Label: count.Pattern, Value: float64(count.Downloads)})
+	}
+	return pieChartSVG(items)
+}
+
+// textDetails lista os downloads dos 3 releases mais recentes
+func (d *DownloadAnalysis) textDetails() []string {
+	var details []string
+	for _, release := range d.Releases[:min(3, len(d.Releases))] {
+		details = append(details, fmt.Sprintf("%s: %s downloads (%d assets)",
+			release.Tag, formatNumber(release.Downloads), release.Assets))
+	}
+	return details
+}
+
+// markdownDetails lista os downloads por release
+func (d *DownloadAnalysis) markdownDetails() string {
+	var md strings.Builder
+	md.WriteString("| Release | Assets | Downloads |\n|---|---:|---:|\n")
+	for _, release := range d.Releases[:min(recentReleasesShown, len(d.Releases))] {
+		md.WriteString(fmt.Sprintf("| %s | %d | %s |\n", release.Tag, release.Assets, formatNumber(release.Downloads)))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
+
+// htmlDetails lista os downloads por release
+func (d *DownloadAnalysis) htmlDetails() string {
+	var page strings.Builder
+	page.WriteString("<table><tr><th>Release</th><th>Assets</th><th>Downloads</th></tr>")
+	for _, release := range d.Releases[:min(recentReleasesShown, len(d.Releases))] {
+		page.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%s</td></tr>",
+			html.EscapeString(release.Tag), release.Assets, formatNumber(release.Downloads)))
+	}
+	page.WriteString("</table>")
+	return page.String()
+}
@@ -0,0 +1,558 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// HealthModel define como o score de saúde é calculado: cada sinal retira
+// pontos de um score inicial de 100 e as faixas traduzem o score em status
+type HealthModel struct {
+	Signals []HealthSignal `json:"signals"`
+	Bands   []HealthBand   `json:"bands"`
+}
+
+// HealthSignal avalia uma métrica do repositório. Os limites são testados em
+// ordem e apenas o primeiro que casar aplica a penalidade, multiplicada pelo
+// peso do sinal (padrão 1 quando ausente; 0 desliga a penalidade mantendo o
+// sinal nos relatórios). Penalidades negativas funcionam como bônus.
+type HealthSignal struct {
+	Name       string            `json:"name"`
+	Label      string            `json:"label,omitempty"`
+	Metric     string            `json:"metric"`
+	Weight     *float64          `json:"weight,omitempty"`
+	Thresholds []HealthThreshold `json:"thresholds"`
+}
+
+// weight retorna o peso do sinal, 1 quando não informado
+func (s HealthSignal) weight() float64 {
+	if s.Weight == nil {
+		return 1
+	}
+	return *s.Weight
+}
+
+// HealthThreshold é uma condição de um sinal e a penalidade aplicada
+type HealthThreshold struct {
+	Op      string  `json:"op"`
+	Value   float64 `json:"value"`
+	Penalty float64 `json:"penalty"`
+}
+
+// HealthBand associa um score mínimo a um status de manutenção
+type HealthBand struct {
+	Min   float64 `json:"min"`
+	Label string  `json:"label"`
+}
+
+// HealthContribution descreve quanto um sinal contribuiu para o score final;
+// NoData indica que a métrica não tinha dados e o sinal não foi aplicado
+type HealthContribution struct {
+	Signal    string       `json:"signal"`
+	Label     string       `json:"label"`
+	Metric    string       `json:"metric"`
+	Value     float64      `json:"value"`
+	NoData    bool         `json:"no_data,omitempty"`
+	Condition string       `json:"condition,omitempty"`
+	Points    float64      `json:"points"`
+	Basis     *SignalBasis `json:"basis"`
+}
+
+// healthMetric descreve uma métrica disponível para os sinais de saúde; sem
+// basis, o valor é considerado exato. As funções leem os dados extraídos e a
+// análise já calculada (a.Health traz os indicadores base, ainda sem score).
+// Métricas sem dados (ex: data ausente) retornam noData.
+type healthMetric struct {
+	label string
+	value func(d *extractor.RepositoryData, a *Analysis) float64
+	basis func(d *extractor.RepositoryData, a *Analysis) *SignalBasis
+}
+
+// contributorsLimit é o número máximo de colaboradores retornados pela extração
+const contributorsLimit = 20
+
+// healthMetrics lista as métricas aceitas no campo "metric" dos sinais
+var healthMetrics = map[string]healthMetric{
+	"last_commit_days": {
+		label: "Dias desde o último commit",
+		value: func(d *extractor.RepositoryData, a *Analysis) float64 {
+			if len(d.RecentCommits) == 0 {
+				return noData
+			}
+			return float64(a.Health.LastCommitDays)
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return latestBasis(len(d.RecentCommits))
+		},
+	},
+	"last_release_days": {
+		label: "Dias desde o último release",
+		value: func(d *extractor.RepositoryData, a *Analysis) float64 {
+			if len(d.Releases) == 0 {
+				return noData
+			}
+			return float64(a.Health.LastReleaseDays)
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return latestBasis(len(d.Releases))
+		},
+	},
+	"open_issues_ratio": {
+		label: "Ratio de issues abertas",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 { return a.Health.OpenIssuesRatio },
+		basis: func(_ *extractor.RepositoryData, a *Analysis) *SignalBasis { return a.Health.OpenIssuesRatioBasis },
+	},
+	"stale_issues": {
+		label: "Issues obsoletas",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 { return float64(a.Health.StaleIssues) },
+		basis: func(_ *extractor.RepositoryData, a *Analysis) *SignalBasis { return a.Health.StaleIssuesBasis },
+	},
+	"last_push_days": {
+		label: "Dias desde o último push",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 { return daysSince(d.BasicInfo.PushedAt) },
+	},
+	"repo_age_days": {
+		label: "Idade do repositório (dias)",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 {
+			return daysSince(d.BasicInfo.CreatedAt)
+		},
+	},
+	"stars": {
+		label: "Stars",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 { return float64(d.Statistics.Stars) },
+	},
+	"forks": {
+		label: "Forks",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 { return float64(d.Statistics.Forks) },
+	},
+	"open_issues": {
+		label: "Issues abertas",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 { return float64(d.Statistics.Issues) },
+	},
+	"contributors": {
+		label: "Colaboradores",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 { return float64(len(d.Contributors)) },
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return listBasis(len(d.Contributors), contributorsLimit)
+		},
+	},
+	"core_team": {
+		label: "Time principal (100+ commits)",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			return float64(a.Contributors.CoreTeamSize)
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return listBasis(len(d.Contributors), contributorsLimit)
+		},
+	},
+	"truck_factor": {
+		label: "Truck factor",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			return float64(a.BusFactor.TruckFactor)
+		},
+		basis: busFactorBasis,
+	},
+	"contributors_gini": {
+		label: "Gini dos commits",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 { return a.BusFactor.Gini },
+		basis: busFactorBasis,
+	},
+	"commits_last_week": {
+		label: "Commits na última semana",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			return float64(a.Activity.CommitsLastWeek)
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return windowBasis(commitTimes(d), extractor.RecentCommitsSize, time.Now().AddDate(0, 0, -7))
+		},
+	},
+	"commits_last_month": {
+		label: "Commits no último mês",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			return float64(a.Activity.CommitsLastMonth)
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return windowBasis(commitTimes(d), extractor.RecentCommitsSize, time.Now().AddDate(0, -1, 0))
+		},
+	},
+	"avg_issue_age_days": {
+		label: "Idade média das issues (dias)",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 { return a.Activity.AvgIssueAge },
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return sampleCountBasis(len(d.RecentIssues))
+		},
+	},
+	"avg_pr_age_days": {
+		label: "Idade média dos PRs (dias)",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 { return a.Activity.AvgPRAge },
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return sampleCountBasis(len(d.RecentPRs))
+		},
+	},
+	"community_missing": {
+		label: "Arquivos de comunidade ausentes",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if community := a.Community; community != nil {
+				return float64(len(community.Missing))
+			}
+			return noData
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			if d.Community == nil {
+				return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+			}
+			return exactBasis
+		},
+	},
+	"codeowners_coverage": {
+		label: "Cobertura do CODEOWNERS (%)",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if codeowners := a.CodeOwners; codeowners != nil && codeowners.TreeAvailable {
+				return codeowners.Coverage
+			}
+			return noData
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			if d.CodeOwners == nil {
+				return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+			}
+			return treeBasis(d, nil)
+		},
+	},
+	"has_license": {
+		label: "Possui licença",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 {
+			return boolMetric(d.BasicInfo.License != "")
+		},
+	},
+	"has_description": {
+		label: "Possui descrição",
+		value: func(d *extractor.RepositoryData, _ *Analysis) float64 {
+			return boolMetric(d.BasicInfo.Description != "")
+		},
+	},
+	"has_ci": {
+		label: "Possui CI configurado",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if a.Tree == nil {
+				return noData
+			}
+			return boolMetric(len(a.Tree.CIConfigs) > 0)
+		},
+		basis: treeBasis,
+	},
+	"tested_packages_ratio": {
+		label: "Pacotes com testes (fração)",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if tree := a.Tree; tree != nil && tree.Packages > 0 {
+				return tree.TestedShare()
+			}
+			return noData
+		},
+		basis: treeBasis,
+	},
+	"days_since_stable_release": {
+		label: "Dias desde o último release estável",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if releases := a.Releases; releases != nil && releases.LastStableTag != "" {
+				return float64(releases.DaysSinceStable)
+			}
+			return noData
+		},
+		basis: func(d *extractor.RepositoryData, a *Analysis) *SignalBasis {
+			if releases := a.Releases; releases == nil || releases.LastStableTag == "" {
+				return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+			}
+			return latestBasis(len(d.Releases))
+		},
+	},
+	"release_interval_median_days": {
+		label: "Mediana de dias entre releases",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if releases := a.Releases; releases != nil {
+				return releases.MedianIntervalDays
+			}
+			return 0
+		},
+		basis: func(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+			return listBasis(len(d.Releases), extractor.ReleaseHistorySize)
+		},
+	},
+	"security_score": {
+		label: "Score de segurança",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if security := a.Security; security != nil {
+				return security.Score
+			}
+			return 100
+		},
+		basis: securityBasis,
+	},
+	"security_critical_alerts": {
+		label: "Alertas de segurança críticos",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if security := a.Security; security != nil {
+				return float64(security.Count(extractor.SeverityCritical))
+			}
+			return 0
+		},
+		basis: securityBasis,
+	},
+	"conventional_commits_ratio": {
+		label: "Fração de Conventional Commits",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if commits := a.Commits; commits != nil && commits.Analyzed > 0 {
+				return commits.ConventionalRatio
+			}
+			return noData
+		},
+		basis: commitHygieneBasis,
+	},
+	"verified_commits_ratio": {
+		label: "Fração de commits verificados",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if commits := a.Commits; commits != nil && commits.VerificationKnown > 0 {
+				return commits.VerifiedRatio
+			}
+			return noData
+		},
+		basis: commitHygieneBasis,
+	},
+	"unlinked_commits_ratio": {
+		label: "Fração de commits sem issue vinculada",
+		value: func(_ *extractor.RepositoryData, a *Analysis) float64 {
+			if commits := a.Commits; commits != nil && commits.Analyzed > 0 {
+				return commits.UnlinkedRatio
+			}
+			return noData
+		},
+		basis: commitHygieneBasis,
+	},
+}
+
+// healthOperators lista as comparações aceitas no campo "op" dos limites
+var healthOperators = map[string]func(value, threshold float64) bool{
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// DefaultHealthModel reproduz as penalidades e os status históricos do
+// analisador, mais o score de segurança (100 quando os alertas não são legíveis)
+var DefaultHealthModel = &HealthModel{
+	Signals: []HealthSignal{
+		{Name: "inactivity", Label: "Inatividade", Metric: "last_commit_days", Thresholds: []HealthThreshold{
+			{Op: ">", Value: 30, Penalty: 20},
+			{Op: ">", Value: 7, Penalty: 10},
+		}},
+		{Name: "release_age", Label: "Releases antigas", Metric: "last_release_days", Thresholds: []HealthThreshold{
+			{Op: ">", Value: 365, Penalty: 15},
+			{Op: ">", Value: 180, Penalty: 10},
+		}},
+		{Name: "open_issues", Label: "Muitas issues abertas", Metric: "open_issues_ratio", Thresholds: []HealthThreshold{
+			{Op: ">", Value: 0.8, Penalty: 15},
+			{Op: ">", Value: 0.6, Penalty: 10},
+		}},
+		{Name: "stale_issues", Label: "Issues obsoletas", Metric: "stale_issues", Thresholds: []HealthThreshold{
+			{Op: ">", Value: 10, Penalty: 10},
+			{Op: ">", Value: 5, Penalty: 5},
+		}},
+		{Name: "security", Label: "Alertas de segurança", Metric: "security_score", Thresholds: []HealthThreshold{
+			{Op: "<", Value: 50, Penalty: 15},
+			{Op: "<", Value: 80, Penalty: 5},
+		}},
+	},
+	Bands: []HealthBand{
+		{Min: 90, Label: "Excelente"},
+		{Min: 80, Label: "Muito Bom"},
+		{Min: 70, Label: "Bom"},
+		{Min: 60, Label: "Regular"},
+		{Min: 50, Label: "Precisa Atenção"},
+		{Min: 0, Label: "Crítico"},
+	},
+}
+
+// LoadHealthModel lê um modelo de saúde de um arquivo JSON. Sem faixas, as
+// faixas do modelo padrão são usadas.
+func LoadHealthModel(path string) (*HealthModel, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler modelo de saúde: %v", err)
+	}
+
+	var model HealthModel
+	if err := json.Unmarshal(content, &model); err != nil {
+		return nil, fmt.Errorf("modelo de saúde inválido em %s: %v", path, err)
+	}
+	if len(model.Bands) == 0 {
+		model.Bands = DefaultHealthModel.Bands
+	}
+	if err := model.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &model, nil
+}
+
+// validate verifica sinais, métricas, operadores e faixas do modelo
+func (m *HealthModel) validate() error {
+	names := make(map[string]bool)
+	for i, signal := range m.Signals {
+		if signal.Name == "" {
+			return fmt.Errorf("sinal %d sem nome", i+1)
+		}
+		if names[signal.Name] {
+			return fmt.Errorf("sinal %q repetido", signal.Name)
+		}
+		names[signal.Name] = true
+
+		if _, ok := healthMetrics[signal.Metric]; !ok {
+			return fmt.Errorf("sinal %q: métrica %q desconhecida (disponíveis: %s)",
+				signal.Name, signal.Metric, strings.Join(HealthMetricNames(), ", "))
+		}
+		if signal.Weight != nil && *signal.Weight < 0 {
+			return fmt.Errorf("sinal %q: peso negativo", signal.Name)
+		}
+		if len(signal.Thresholds) == 0 {
+			return fmt.Errorf("sinal %q sem limites", signal.Name)
+		}
+		for _, threshold := range signal.Thresholds {
+			if _, ok := healthOperators[threshold.Op]; !ok {
+				return fmt.Errorf("sinal %q: operador %q inválido (use <, <=, >, >=, == ou !=)", signal.Name, threshold.Op)
+			}
+		}
+	}
+
+	if len(m.Bands) == 0 {
+		return fmt.Errorf("nenhuma faixa de status definida")
+	}
+	for i, band := range m.Bands {
+		if band.Label == "" {
+			return fmt.Errorf("faixa %d sem label", i+1)
+		}
+	}
+	return nil
+}
+
+// HealthMetricNames retorna as métricas disponíveis para os sinais em ordem alfabética
+func HealthMetricNames() []string {
+	names := make([]string, 0, len(healthMetrics))
+	for name := range healthMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Score aplica os sinais do modelo à análise e retorna o score (0-100), o
+// status e a contribuição de cada sinal, na ordem em que foram definidos.
+// analysis traz os resultados dos analisadores e os indicadores base em Health.
+func (m *HealthModel) Score(data *extractor.RepositoryData, analysis *Analysis) (float64, string, []*HealthContribution) {
+	score := 100.0
+	contributions := make([]*HealthContribution, 0, len(m.Signals))
+
+	for _, signal := range m.Signals {
+		metric := healthMetrics[signal.Metric]
+		value := metric.value(data, analysis)
+		missing := math.IsNaN(value)
+
+		label := signal.Label
+		if label == "" {
+			label = metric.label
+		}
+		contribution := &HealthContribution{
+			Signal: signal.Name,
+			Label:  label,
+			Metric: signal.Metric,
+			Value:  value,
+			Basis:  exactBasis,
+		}
+		if metric.basis != nil {
+			if basis := metric.basis(data, analysis); basis != nil {
+				contribution.Basis = basis
+			}
+		}
+		if missing {
+			// Sem dados o sinal não penaliza nem bonifica
+			contribution.Value, contribution.NoData = 0, true
+			contribution.Basis = &SignalBasis{Source: contribution.Basis.Source, Confidence: ConfidenceLow}
+			contributions = append(contributions, contribution)
+			continue
+		}
+
+		for _, threshold := range signal.Thresholds {
+			if !healthOperators[threshold.Op](value, threshold.Value) {
+				continue
+			}
+			contribution.Condition = fmt.Sprintf("%s %s %g", formatHealthValue(value), threshold.Op, threshold.Value)
+			contribution.Points = -threshold.Penalty * signal.weight()
+			score += contribution.Points
+			break
+		}
+
+		contributions = append(contributions, contribution)
+	}
+
+	score = math.Max(0, math.Min(100, score))
+	return score, m.band(score), contributions
+}
+
+// band retorna o status da maior faixa atingida pelo score; abaixo de todas,
+// vale a faixa de menor mínimo
+func (m *HealthModel) band(score float64) string {
+	bands := make([]HealthBand, len(m.Bands))
+	copy(bands, m.Bands)
+	sort.SliceStable(bands, func(i, j int) bool { return bands[i].Min > bands[j].Min })
+
+	for _, band := range bands {
+		if score >= band.Min {
+			return band.Label
+		}
+	}
+	return bands[len(bands)-1].Label
+}
+
+// noData marca métricas sem dados; o NaN não casa com nenhum limite e é
+// tratado à parte em Score
+var noData = math.NaN()
+
+// daysSince retorna os dias decorridos desde a data (noData quando ausente)
+func daysSince(t time.Time) float64 {
+	if t.IsZero() {
+		return noData
+	}
+	return math.Floor(time.Since(t).Hours() / 24)
+}
+
+// boolMetric converte condições em 1 (verdadeiro) ou 0 (falso)
+func boolMetric(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// valueText formata o valor da métrica do sinal, ou "sem dados"
+func (c *HealthContribution) valueText() string {
+	if c.NoData {
+		return "sem dados"
+	}
+	return formatHealthValue(c.Value)
+}
+
+// formatHealthValue formata valores inteiros sem casas decimais
+func formatHealthValue(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
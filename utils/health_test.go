@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+func TestScoreNoData(t *testing.T) {
+	now := time.Now()
+	unsigned := &extractor.CommitData{SHA: "a1", Message: "fix: corrige o parser", CreatedAt: now}
+	verified := &extractor.CommitData{SHA: "b2", Message: "feat: novo comando\n\nFixes #12", CreatedAt: now, Verification: "valid", Verified: true}
+	merge := &extractor.CommitData{SHA: "c3", Message: "Merge branch 'main' into dev", CreatedAt: now, Parents: 2}
+
+	tests := []struct {
+		name   string
+		metric string
+		data   extractor.RepositoryData
+		noData bool
+		value  float64
+	}{
+		{"conventional sem commits", "conventional_commits_ratio", extractor.RepositoryData{}, true, 0},
+		{"conventional só com merges", "conventional_commits_ratio", extractor.RepositoryData{RecentCommits: []*extractor.CommitData{merge}}, true, 0},
+		{"conventional com commits", "conventional_commits_ratio", extractor.RepositoryData{RecentCommits: []*extractor.CommitData{unsigned, merge}}, false, 1},
+		{"verified sem commits", "verified_commits_ratio", extractor.RepositoryData{}, true, 0},
+		{"verified sem dados de verificação", "verified_commits_ratio", extractor.RepositoryData{RecentCommits: []*extractor.CommitData{unsigned}}, true, 0},
+		{"verified com dados de verificação", "verified_commits_ratio", extractor.RepositoryData{RecentCommits: []*extractor.CommitData{unsigned, verified}}, false, 1},
+		{"unlinked sem commits", "unlinked_commits_ratio", extractor.RepositoryData{}, true, 0},
+		{"unlinked com commits", "unlinked_commits_ratio", extractor.RepositoryData{RecentCommits: []*extractor.CommitData{unsigned, verified}}, false, 0.5},
+		{"community sem verificação", "community_missing", extractor.RepositoryData{}, true, 0},
+		{"community verificada", "community_missing", extractor.RepositoryData{Community: &extractor.CommunityProfile{
+			Files: map[string]*extractor.CommunityFile{extractor.CommunityReadme: {Present: true}}}}, false, 6},
+		{"codeowners sem verificação", "codeowners_coverage", extractor.RepositoryData{}, true, 0},
+		{"codeowners sem árvore", "codeowners_coverage", extractor.RepositoryData{CodeOwners: &extractor.CodeOwnersFile{}}, true, 0},
+		{"codeowners com árvore", "codeowners_coverage", extractor.RepositoryData{CodeOwners: &extractor.CodeOwnersFile{},
+			Tree: &extractor.RepositoryTree{Files: []*extractor.TreeFile{{Path: "main.go"}}}}, false, 0},
+		{"tested sem árvore", "tested_packages_ratio", extractor.RepositoryData{}, true, 0},
+		{"tested sem pacotes", "tested_packages_ratio", extractor.RepositoryData{
+			Tree: &extractor.RepositoryTree{Files: []*extractor.TreeFile{{Path: "README.md"}}}}, true, 0},
+		{"tested com pacotes", "tested_packages_ratio", extractor.RepositoryData{
+			Tree: &extractor.RepositoryTree{Files: []*extractor.TreeFile{{Path: "pkg/a.go"}, {Path: "pkg/a_test.go"}, {Path: "cmd/main.go"}}}}, false, 0.5},
+		{"has_ci sem árvore", "has_ci", extractor.RepositoryData{}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &HealthModel{
+				Signals: []HealthSignal{{Name: "sinal", Metric: tt.metric, Thresholds: []HealthThreshold{{Op: "<", Value: 7, Penalty: 10}}}},
+				Bands:   DefaultHealthModel.Bands,
+			}
+			data := tt.data
+			data.BasicInfo = &extractor.BasicInfo{FullName: "octo/app"}
+			data.Statistics = &extractor.Statistics{}
+
+			health := Analyzer{HealthModel: model}.Analyze(&data).Health
+			contribution := health.Contributions[0]
+			if contribution.NoData != tt.noData {
+				t.Fatalf("NoData = %v, esperado %v (valor %v)", contribution.NoData, tt.noData, contribution.Value)
+			}
+			if contribution.Value != tt.value {
+				t.Errorf("valor = %v, esperado %v", contribution.Value, tt.value)
+			}
+			if wantPoints := map[bool]float64{true: 0, false: -10}[tt.noData]; contribution.Points != wantPoints {
+				t.Errorf("pontos = %v, esperado %v", contribution.Points, wantPoints)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// dashboardCSS contém o estilo embutido do dashboard HTML
+const dashboardCSS = `
+* { box-sizing: border-box; }
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; background: #f6f8fa; color: #24292f; }
+header { background: #24292f; color: #fff; padding: 1.5rem 2rem; }
+header a { color: #fff; }
+header p { margin: 0.25rem 0 0; color: #d0d7de; }
+main { padding: 1.5rem 2rem; display: grid; grid-template-columns: repeat(auto-fit, minmax(420px, 1fr)); gap: 1.25rem; }
+section { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 1rem 1.25rem; }
+section.wide { grid-column: 1 / -1; }
+h2 { font-size: 1.1rem; margin: 0 0 0.75rem; }
+.stats { display: flex; flex-wrap: wrap; gap: 1rem; }
+.stat { flex: 1 1 120px; background: #f6f8fa; border-radius: 6px; padding: 0.75rem; text-align: center; }
+.stat strong { display: block; font-size: 1.5rem; }
+table { width: 100%; border-collapse: collapse; font-size: 0.9rem; }
+th, td { border-bottom: 1px solid #eaeef2; padding: 0.4rem 0.5rem; text-align: left; }
+a { color: #0969da; text-decoration: none; }
+svg { width: 100%; height: auto; max-height: 320px; font-family: inherit; }
+footer { padding: 0 2rem 2rem; color: #57606a; font-size: 0.85rem; }
+`
+
+// GenerateHTMLReport gera um dashboard HTML autocontido, com CSS embutido e
+// gráficos SVG gerados no servidor, sem dependências externas de JS ou CDN
+func GenerateHTMLReport(data *extractor.RepositoryData, analysis *Analysis) string {
+	var page strings.Builder
+	esc := html.EscapeString
+	repoURL := data.BasicInfo.URL
+
+	languages := analysis.Languages
+	activity := analysis.Activity
+	contributors := analysis.Contributors
+	health := analysis.Health
+
+	page.WriteString("<!DOCTYPE html>\n<html lang=\"pt-BR\">\n<head>\n<meta charset=\"utf-8\">\n")
+	page.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	page.WriteString(fmt.Sprintf("<title>%s — Relatório de análise</title>\n", esc(data.BasicInfo.FullName)))
+	page.WriteString("<style>" + dashboardCSS + "</style>\n</head>\n<body>\n")
+
+	// Cabeçalho
+	page.WriteString(fmt.Sprintf("<header><h1>📊 <a href=\"%s\">%s</a></h1><p>%s</p></header>\n",
+		esc(repoURL), esc(data.BasicInfo.FullName), esc(data.BasicInfo.Description)))
+	page.WriteString("<main>\n")
+
+	// Estatísticas
+	page.WriteString("<section class=\"wide\"><h2>📈 Estatísticas</h2><div class=\"stats\">")
+	for _, stat := range []struct {
+		label string
+		value int
+	}{
+		{"⭐ Stars", data.Statistics.Stars},
+		{"🍴 Forks", data.Statistics.Forks},
+		{"👀 Watchers", data.Statistics.Watchers},
+		{"🎯 Issues abertas", data.Statistics.Issues},
+		{"👥 Colaboradores", contributors.TotalContributors},
+	} {
+		page.WriteString(fmt.Sprintf("<div class=\"stat\"><strong>%s</strong>%s</div>", formatNumber(stat.value), stat.label))
+	}
+	page.WriteString("</div></section>\n")
+
+	// Saúde
+	page.WriteString("<section><h2>🏥 Saúde do repositório</h2>")
+	page.WriteString(gaugeSVG(health.HealthScore, health.MaintenanceStatus))
+	page.WriteString("<table>")
+	page.WriteString(fmt.Sprintf("<tr><td>Último commit</td><td>%d dias atrás</td></tr>", health.LastCommitDays))
+	page.WriteString(fmt.Sprintf("<tr><td>Último release</td><td>%d dias atrás</td></tr>", health.LastReleaseDays))
+	page.WriteString(fmt.Sprintf("<tr><td>Issues obsoletas</td><td>%d <small>(%s)</small></td></tr>",
+		health.StaleIssues, esc(health.StaleIssuesBasis.String())))
+	page.WriteString(fmt.Sprintf("<tr><td>Ratio de issues abertas</td><td>%.1f%% <small>(%s)</small></td></tr>",
+		health.OpenIssuesRatio*100, esc(health.OpenIssuesRatioBasis.String())))
+	page.WriteString("</table>")
+	if len(health.Contributions) > 0 {
+		page.WriteString("<h3>Contribuição de cada sinal</h3><table><tr><th>Sinal</th><th>Valor</th><th>Condição</th><th>Pontos</th><th>Base</th></tr>")
+		for _, c := range health.Contributions {
+			page.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%+.1f</td><td>%s</td></tr>",
+				esc(c.Label), c.valueText(), esc(valueOrDash(c.Condition)), c.Points, esc(c.Basis.String())))
+		}
+		page.WriteString("</table>")
+	}
+	page.WriteString("</section>\n")
+
+	// Linguagens
+	var languageItems []chartItem
+	for i, lang := range languages {
+		if i >= 8 { // Top 8, o restante é agrupado
+			rest := 0.0
+			for _, other := range languages[i:] {
+				rest += float64(other.Bytes)
+			}
+			languageItems = append(languageItems, chartItem{Label: "Outras", Value: rest})
+			break
+		}
+		languageItems = append(languageItems, chartItem{Label: lang.Name, Value: float64(lang.Bytes)})
+	}
+	page.WriteString("<section><h2>💻 Linguagens</h2>")
+	page.WriteString(pieChartSVG(languageItems))
+	page.WriteString("</section>\n")
+
+	// Atividade
+	page.WriteString("<section><h2>⚡ Atividade recente</h2>")
+	page.WriteString(barChartSVG([]chartItem{
+		{Label: "Commits 7d", Value: float64(activity.CommitsLastWeek)},
+		{Label: "Commits 30d", Value: float64(activity.CommitsLastMonth)},
+		{Label: "Issues 7d", Value: float64(activity.IssuesLastWeek)},
+		{Label: "Issues 30d", Value: float64(activity.IssuesLastMonth)},
+		{Label: "PRs 7d", Value: float64(activity.PRsLastWeek)},
+		{Label: "PRs 30d", Value: float64(activity.PRsLastMonth)},
+	}))
+	page.WriteString(fmt.Sprintf("<p>Idade média das issues: <strong>%.1f dias</strong> · Idade média dos PRs: <strong>%.1f dias</strong></p>",
+		activity.AvgIssueAge, activity.AvgPRAge))
+	page.WriteString("</section>\n")
+
+	// Colaboradores
+	var contributorItems []chartItem
+	for _, contrib := range contributors.TopContributors {
+		contributorItems = append(contributorItems, chartItem{Label: contrib.Login, Value: float64(contrib.Contributions)})
+	}
+	page.WriteString("<section><h2>👥 Distribuição de contribuições</h2>")
+	page.WriteString(horizontalBarChartSVG(contributorItems))
+	page.WriteString(fmt.Sprintf("<p>Time principal (100+ commits): <strong>%d</strong></p>", contributors.CoreTeamSize))
+	page.WriteString("</section>\n")
+
+	// Releases
+	if len(data.Releases) > 0 {
+		page.WriteString("<section><h2>🚀 Releases</h2><table><tr><th>Tag</th><th>Publicado em</th><th>Tipo</th></tr>")
+		for i, release := range data.Releases {
+			if i >= recentReleasesShown {
+				break
+			}
+			page.WriteString(fmt.Sprintf("<tr><td><a href=\"%s/releases/tag/%s\">%s</a></td><td>%s</td><td>%s</td></tr>",
+				esc(repoURL), esc(url.PathEscape(release.TagName)), esc(release.TagName),
+				release.PublishedAt.Format("02/01/2006"), releaseKind(release)))
+		}
+		page.WriteString("</table></section>\n")
+	}
+
+	// Seções de indicadores das análises
+	writeHTMLSections(&page, analysis.sections())
+
+	// Issues e PRs recentes
+	if len(data.RecentIssues) > 0 {
+		page.WriteString("<section><h2>🎯 Issues recentes</h2><table><tr><th>#</th><th>Título</th><th>Estado</th></tr>")
+		for _, issue := range data.RecentIssues {
+			page.WriteString(fmt.Sprintf("<tr><td><a href=\"%s/issues/%d\">#%d</a></td><td>%s</td><td>%s</td></tr>",
+				esc(repoURL), issue.Number, issue.Number, esc(issue.Title), esc(issue.State)))
+		}
+		page.WriteString("</table></section>\n")
+	}
+
+	if len(data.RecentPRs) > 0 {
+		page.WriteString("<section><h2>🔄 Pull requests recentes</h2><table><tr><th>#</th><th>Título</th><th>Estado</th></tr>")
+		for _, pr := range data.RecentPRs {
+			page.WriteString(fmt.Sprintf("<tr><td><a href=\"%s/pull/%d\">#%d</a></td><td>%s</td><td>%s</td></tr>",
+				esc(repoURL), pr.Number, pr.Number, esc(pr.Title), pullRequestState(pr)))
+		}
+		page.WriteString("</table></section>\n")
+	}
+
+	// Commits recentes
+	if len(data.RecentCommits) > 0 {
+		page.WriteString("<section class=\"wide\"><h2>📝 Commits recentes</h2><table><tr><th>Commit</th><th>Mensagem</th><th>Autor</th><th>Data</th></tr>")
+		for _, commit := range data.RecentCommits {
+			page.WriteString(fmt.Sprintf("<tr><td><a href=\"%s\"><code>%s</code></a></td><td>%s</td><td>%s</td><td>%s</td></tr>",
+				esc(commit.URL), shortSHA(commit.SHA), esc(commitSubject(commit.Message)),
+				esc(commit.Author), commit.CreatedAt.Format("02/01/2006 15:04")))
+		}
+		page.WriteString("</table></section>\n")
+	}
+
+	page.WriteString("</main>\n")
+	page.WriteString(fmt.Sprintf("<footer>Relatório gerado em %s.</footer>\n", time.Now().Format("02/01/2006 15:04:05")))
+	page.WriteString("</body>\n</html>\n")
+
+	return page.String()
+}
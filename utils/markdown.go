@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// GenerateMarkdownReport gera o relatório completo em Markdown, com tabelas e
+// links para issues, PRs, commits e releases, pronto para wikis ou comentários
+func GenerateMarkdownReport(data *extractor.RepositoryData, analysis *Analysis) string {
+	var md strings.Builder
+	repoURL := data.BasicInfo.URL
+
+	md.WriteString(fmt.Sprintf("# 📊 Relatório de análise: [%s](%s)\n\n", data.BasicInfo.FullName, repoURL))
+	if data.BasicInfo.Description != "" {
+		md.WriteString(fmt.Sprintf("> %s\n\n", escapeMarkdownCell(data.BasicInfo.Description)))
+	}
+
+	// Informações básicas
+	md.WriteString("## 📋 Informações básicas\n\n")
+	md.WriteString("| Campo | Valor |\n|---|---|\n")
+	md.WriteString(fmt.Sprintf("| Proprietário | [%s](https://github.com/%s) |\n", data.BasicInfo.Owner, data.BasicInfo.Owner))
+	md.WriteString(fmt.Sprintf("| Branch padrão | `%s` |\n", data.BasicInfo.DefaultBranch))
+	md.WriteString(fmt.Sprintf("| Criado em | %s |\n", data.BasicInfo.CreatedAt.Format("02/01/2006")))
+	md.WriteString(fmt.Sprintf("| Último push | %s |\n", data.BasicInfo.PushedAt.Format("02/01/2006 15:04")))
+	md.WriteString(fmt.Sprintf("| Licença | %s |\n", valueOrDash(data.BasicInfo.License)))
+	md.WriteString(fmt.Sprintf("| Tamanho | %d KB |\n", data.BasicInfo.Size))
+	if data.BasicInfo.Homepage != "" {
+		md.WriteString(fmt.Sprintf("| Homepage | <%s> |\n", data.BasicInfo.Homepage))
+	}
+	if len(data.Topics) > 0 {
+		topics := make([]string, len(data.Topics))
+		for i, topic := range data.Topics {
+			topics[i] = "`" + topic + "`"
+		}
+		md.WriteString(fmt.Sprintf("| Tópicos | %s |\n", strings.Join(topics, " ")))
+	}
+	md.WriteString("\n")
+
+	// Estatísticas
+	md.WriteString("## 📈 Estatísticas\n\n")
+	md.WriteString("| ⭐ Stars | 🍴 Forks | 👀 Watchers | 🎯 Issues abertas |\n|---:|---:|---:|---:|\n")
+	md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n\n",
+		formatNumber(data.Statistics.Stars),
+		formatNumber(data.Statistics.Forks),
+		formatNumber(data.Statistics.Watchers),
+		formatNumber(data.Statistics.Issues)))
+
+	// Linguagens
+	languages := analysis.Languages
+	if len(languages) > 0 {
+		md.WriteString("## 💻 Linguagens\n\n")
+		md.WriteString("| Linguagem | Bytes | % |\n|---|---:|---:|\n")
+		for _, lang := range languages {
+			md.WriteString(fmt.Sprintf("| %s | %d | %.1f%% |\n", lang.Name, lang.Bytes, lang.Percentage))
+		}
+		md.WriteString("\n")
+	}
+
+	// Atividade
+	activity := analysis.Activity
+	md.WriteString("## ⚡ Atividade recente\n\n")
+	md.WriteString("| Métrica | Última semana | Último mês |\n|---|---:|---:|\n")
+	md.WriteString(fmt.Sprintf("| Commits | %d | %d |\n", activity.CommitsLastWeek, activity.CommitsLastMonth))
+	md.WriteString(fmt.Sprintf("| Issues | %d | %d |\n", activity.IssuesLastWeek, activity.IssuesLastMonth))
+	md.WriteString(fmt.Sprintf("| Pull requests | %d | %d |\n\n", activity.PRsLastWeek, activity.PRsLastMonth))
+	md.WriteString(fmt.Sprintf("Idade média das issues: **%.1f dias** · Idade média dos PRs: **%.1f dias**\n\n",
+		activity.AvgIssueAge, activity.AvgPRAge))
+
+	// Colaboradores
+	contributors := analysis.Contributors
+	md.WriteString("## 👥 Colaboradores\n\n")
+	md.WriteString(fmt.Sprintf("Total de colaboradores: **%d** · Time principal (100+ commits): **%d**\n\n",
+		contributors.TotalContributors, contributors.CoreTeamSize))
+	if len(contributors.TopContributors) > 0 {
+		md.WriteString("| # | Colaborador | Contribuições |\n|---:|---|---:|\n")
+		for i, contrib := range contributors.TopContributors {
+			md.WriteString(fmt.Sprintf("| %d | [%s](https://github.com/%s) | %d |\n",
+				i+1, contrib.Login, contrib.Login, contrib.Contributions))
+		}
+		md.WriteString("\n")
+	}
+
+	// Saúde
+	health := analysis.Health
+	md.WriteString("## 🏥 Saúde do repositório\n\n")
+	md.WriteString(fmt.Sprintf("**Score: %.1f/100 — %s**\n\n", health.HealthScore, health.MaintenanceStatus))
+	md.WriteString("| Sinal | Valor |\n|---|---:|\n")
+	md.WriteString(fmt.Sprintf("| Último commit | %d dias atrás |\n", health.LastCommitDays))
+	md.WriteString(fmt.Sprintf("| Último release | %d dias atrás |\n", health.LastReleaseDays))
+	md.WriteString(fmt.Sprintf("| Issues obsoletas | %d (%s) |\n", health.StaleIssues, health.StaleIssuesBasis))
+	md.WriteString(fmt.Sprintf("| Ratio de issues abertas | %.1f%% (%s) |\n\n", health.OpenIssuesRatio*100, health.OpenIssuesRatioBasis))
+	if len(health.Contributions) > 0 {
+		md.WriteString("**Contribuição de cada sinal** (score inicial 100)\n\n")
+		md.WriteString("| Sinal | Valor | Condição | Pontos | Base |\n|---|---:|---|---:|---|\n")
+		for _, c := range health.Contributions {
+			md.WriteString(fmt.Sprintf("| %s | %s | %s | %+.1f | %s |\n",
+				escapeMarkdownCell(c.Label), c.valueText(),
+				escapeMarkdownCell(valueOrDash(c.Condition)), c.Points, c.Basis))
+		}
+		md.WriteString("\n")
+	}
+
+	// Releases
+	if len(data.Releases) > 0 {
+		md.WriteString("## 🚀 Releases\n\n")
+		md.WriteString("| Tag | Nome | Publicado em | Autor | Tipo |\n|---|---|---|---|---|\n")
+		for i, release := range data.Releases {
+			if i >= recentReleasesShown {
+				break
+			}
+			md.WriteString(fmt.Sprintf("| [%s](%s/releases/tag/%s) | %s | %s | %s | %s |\n",
+				escapeMarkdownCell(release.TagName), repoURL, url.PathEscape(release.TagName),
+				escapeMarkdownCell(valueOrDash(release.Name)),
+				release.PublishedAt.Format("02/01/2006"),
+				escapeMarkdownCell(valueOrDash(release.Author)),
+				releaseKind(release)))
+		}
+		md.WriteString("\n")
+	}
+
+	// Seções de indicadores das análises
+	writeMarkdownSections(&md, analysis.sections())
+
+	// Issues recentes
+	if len(data.RecentIssues) > 0 {
+		md.WriteString("## 🎯 Issues recentes\n\n")
+		md.WriteString("| Issue | Título | Estado | Autor | Comentários | Atualizada em |\n|---|---|---|---|---:|---|\n")
+		for _, issue := range data.RecentIssues {
+			md.WriteString(fmt.Sprintf("| [#%d](%s/issues/%d) | %s | %s | %s | %d | %s |\n",
+				issue.Number, repoURL, issue.Number,
+				escapeMarkdownCell(issue.Title),
+				issue.State,
+				issue.Author,
+				issue.Comments,
+				issue.UpdatedAt.Format("02/01/2006")))
+		}
+		md.WriteString("\n")
+	}
+
+	// Pull requests recentes
+	if len(data.RecentPRs) > 0 {
+		md.WriteString("## 🔄 Pull requests recentes\n\n")
+		md.WriteString("| PR | Título | Estado | Autor | Atualizado em |\n|---|---|---|---|---|\n")
+		for _, pr := range data.RecentPRs {
+			md.WriteString(fmt.Sprintf("| [#%d](%s/pull/%d) | %s | %s | %s | %s |\n",
+				pr.Number, repoURL, pr.Number,
+				escapeMarkdownCell(pr.Title),
+				pullRequestState(pr),
+				pr.Author,
+				pr.UpdatedAt.Format("02/01/2006")))
+		}
+		md.WriteString("\n")
+	}
+
+	// Commits recentes
+	if len(data.RecentCommits) > 0 {
+		md.WriteString("## 📝 Commits recentes\n\n")
+		md.WriteString("| Commit | Mensagem | Autor | Data |\n|---|---|---|---|\n")
+		for _, commit := range data.RecentCommits {
+			md.WriteString(fmt.Sprintf("| [`%s`](%s) | %s | %s | %s |\n",
+				shortSHA(commit.SHA), commit.URL,
+				escapeMarkdownCell(commitSubject(commit.Message)),
+				escapeMarkdownCell(commit.Author),
+				commit.CreatedAt.Format("02/01/2006 15:04")))
+		}
+		md.WriteString("\n")
+	}
+
+	md.WriteString("---\n\n")
+	md.WriteString(fmt.Sprintf("_Relatório gerado em %s._\n", time.Now().Format("02/01/2006 15:04:05")))
+
+	return md.String()
+}
+
+// recentReleasesShown é o número de releases listados nos relatórios; os
+// demais entram apenas na análise de cadência
+const recentReleasesShown = 10
+
+// releaseKind descreve o tipo de um release
+func releaseKind(release *extractor.ReleaseData) string {
+	switch {
+	case release.Draft:
+		return "rascunho"
+	case release.Prerelease:
+		return "pré-release"
+	default:
+		return "estável"
+	}
+}
+
+// pullRequestState descreve o estado de um PR considerando merge e draft
+func pullRequestState(pr *extractor.PullRequestData) string {
+	switch {
+	case pr.Merged:
+		return "merged"
+	case pr.Draft && pr.State == "open":
+		return "draft"
+	default:
+		return pr.State
+	}
+}
+
+// commitSubject retorna a primeira linha da mensagem de commit
+func commitSubject(message string) string {
+	if idx := strings.Index(message, "\n"); idx >= 0 {
+		return strings.TrimSpace(message[:idx])
+	}
+	return strings.TrimSpace(message)
+}
+
+// shortSHA abrevia um SHA para 7 caracteres
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+func TestGenerateMarkdownReportEscapesRows(t *testing.T) {
+	data := &extractor.RepositoryData{
+		BasicInfo:  &extractor.BasicInfo{FullName: "octo/app", URL: "https://github.com/octo/app"},
+		Statistics: &extractor.Statistics{},
+		Releases: []*extractor.ReleaseData{
+			{TagName: "api/v1.0.0#rc", Name: "API", Author: "dev|bot", PublishedAt: time.Now()},
+		},
+		RecentCommits: []*extractor.CommitData{
+			{SHA: "abc1234def", Message: "fix: corrige o parser", Author: "Ana | Dev", URL: "https://github.com/octo/app/commit/abc1234def", CreatedAt: time.Now()},
+		},
+	}
+
+	markdown := GenerateMarkdownReport(data, Analyzer{}.Analyze(data))
+	for _, want := range []string{
+		"| [api/v1.0.0#rc](https://github.com/octo/app/releases/tag/api%2Fv1.0.0%23rc) | API |",
+		`| dev\|bot |`,
+		`| fix: corrige o parser | Ana \| Dev |`,
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("relatório não contém %q", want)
+		}
+	}
+}
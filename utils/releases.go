@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// Tipos de release conforme o versionamento semântico
+const (
+	ReleaseInitial    = "initial"
+	ReleaseMajor      = "major"
+	ReleaseMinor      = "minor"
+	ReleasePatch      = "patch"
+	ReleasePrerelease = "prerelease"
+)
+
+// Regularidade da cadência de releases, pelo coeficiente de variação dos intervalos
+const (
+	CadenceRegular      = "regular"
+	CadenceVariable     = "variável"
+	CadenceIrregular    = "irregular"
+	CadenceInsufficient = "insuficiente"
+)
+
+// cadenceMinIntervals é o mínimo de intervalos para avaliar a regularidade
+const cadenceMinIntervals = 3
+
+// ReleaseAnalysis resume o versionamento e a cadência dos releases publicados
+type ReleaseAnalysis struct {
+	// Published conta os releases publicados (rascunhos ficam de fora)
+	Published  int      `json:"published"`
+	Drafts     int      `json:"drafts"`
+	Semver     int      `json:"semver"`
+	NonSemver  []string `json:"non_semver,omitempty"`
+	Major      int      `json:"major"`
+	Minor      int      `json:"minor"`
+	Patch      int      `json:"patch"`
+	Prerelease int      `json:"prerelease"`
+	// Latest é a maior versão estável
+	Latest string `json:"latest,omitempty"`
+	// Breaking são as tags que quebram compatibilidade: majors e, em 0.x, minors
+	Breaking []string          `json:"breaking,omitempty"`
+	Versions []*ReleaseVersion `json:"versions"`
+
+	Intervals          int     `json:"intervals"`
+	MeanIntervalDays   float64 `json:"mean_interval_days"`
+	MedianIntervalDays float64 `json:"median_interval_days"`
+	// IntervalCV é o coeficiente de variação (desvio padrão / média) dos intervalos
+	IntervalCV float64 `json:"interval_cv"`
+	Cadence    string  `json:"cadence"`
+
+	LastStableTag   string `json:"last_stable_tag,omitempty"`
+	DaysSinceStable int    `json:"days_since_stable"`
+}
+
+// ReleaseVersion é um release com a versão semântica e o tipo de mudança
+type ReleaseVersion struct {
+	Tag         string    `json:"tag"`
+	Version     *SemVer   `json:"version"`
+	Kind        string    `json:"kind"`
+	Breaking    bool      `json:"breaking"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// AnalyzeReleases interpreta as tags como versões semânticas, classifica cada
+// release estável pela versão estável anterior e mede a cadência entre os
+// releases publicados; retorna nil quando não há releases
+func AnalyzeReleases(data *extractor.RepositoryData) *ReleaseAnalysis {
+	if len(data.Releases) == 0 {
+		return nil
+	}
+
+	analysis := &ReleaseAnalysis{Cadence: CadenceInsufficient}
+	var published []time.Time
+	var lastStable *extractor.ReleaseData
+	for _, release := range data.Releases {
+		if release.Draft {
+			analysis.Drafts++
+			continue
+		}
+		analysis.Published++
+		if !release.PublishedAt.IsZero() {
+			published = append(published, release.PublishedAt)
+		}
+
+		version, ok := ParseSemVer(release.TagName)
+		if !ok {
+			analysis.NonSemver = append(analysis.NonSemver, release.TagName)
+		} else {
+			analysis.Versions = append(analysis.Versions, &ReleaseVersion{
+				Tag:         release.TagName,
+				Version:     version,
+				PublishedAt: release.PublishedAt,
+			})
+		}
+
+		stable := !release.Prerelease && (!ok || version.Prerelease == "")
+		if stable && (lastStable == nil || release.PublishedAt.After(lastStable.PublishedAt)) {
+			lastStable = release
+		}
+	}
+	analysis.Semver = len(analysis.Versions)
+
+	analysis.classify(data.Releases)
+	analysis.measureCadence(published)
+
+	if lastStable != nil {
+		analysis.LastStableTag = lastStable.TagName
+		analysis.DaysSinceStable = int(daysSince(lastStable.PublishedAt))
+	}
+	return analysis
+}
+
+// classify ordena as versões (da maior para a menor) e define o tipo de cada
+// uma; pré-releases pelo sufixo da versão ou pela marcação do GitHub
+func (r *ReleaseAnalysis) classify(releases []*extractor.ReleaseData) {
+	flagged := make(map[string]bool)
+	for _, release := range releases {
+		if release.Prerelease {
+			flagged[release.TagName] = true
+		}
+	}
+
+	sort.SliceStable(r.Versions, func(i, j int) bool {
+		return r.Versions[i].Version.Compare(r.Versions[j].Version) < 0
+	})
+
+	var previous *SemVer
+	for _, release := range r.Versions {
+		version := release.Version
+		if version.Prerelease != "" || flagged[release.Tag] {
+			release.Kind = ReleasePrerelease
+			r.Prerelease++
+			continue
+		}
+
+		switch {
+		case previous == nil:
+			release.Kind = ReleaseInitial
+		case version.Major != previous.Major:
+			release.Kind = ReleaseMajor
+			r.Major++
+		case version.Minor != previous.Minor:
+			release.Kind = ReleaseMinor
+			r.Minor++
+		default:
+			release.Kind = ReleasePatch
+			r.Patch++
+		}
+		// Em 0.x qualquer minor pode quebrar compatibilidade (semver, item 4)
+		release.Breaking = release.Kind == ReleaseMajor || (release.Kind == ReleaseMinor && version.Major == 0)
+		previous = version
+		r.Latest = release.Tag
+	}
+
+	for i, j := 0, len(r.Versions)-1; i < j; i, j = i+1, j-1 {
+		r.Versions[i], r.Versions[j] = r.Versions[j], r.Versions[i]
+	}
+	for _, release := range r.Versions {
+		if release.Breaking {
+			r.Breaking = append(r.Breaking, release.Tag)
+		}
+	}
+}
+
+// measureCadence calcula média, mediana e coeficiente de variação dos dias
+// entre releases publicados consecutivos
+func (r *ReleaseAnalysis) measureCadence(dates []time.Time) {
+	if len(dates) < 2 {
+		return
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	intervals := make([]float64, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		intervals[i-1] = dates[i].Sub(dates[i-1]).Hours() / 24
+	}
+	r.Intervals = len(intervals)
+
+	mean := 0.0
+	for _, interval := range intervals {
+		mean += interval / float64(len(intervals))
+	}
+	variance := 0.0
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	variance /= float64(len(intervals))
+
+	sorted := append([]float64(nil), intervals...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	r.MeanIntervalDays = math.Round(mean*10) / 10
+	r.MedianIntervalDays = math.Round(median*10) / 10
+	if mean > 0 {
+		r.IntervalCV = math.Round(math.Sqrt(variance)/mean*100) / 100
+	}
+
+	switch {
+	case len(intervals) < cadenceMinIntervals:
+		r.Cadence = CadenceInsufficient
+	case r.IntervalCV <= 0.5:
+		r.Cadence = CadenceRegular
+	case r.IntervalCV <= 1:
+		r.Cadence = CadenceVariable
+	default:
+		r.Cadence = CadenceIrregular
+	}
+}
+
+// summary resume os releases em uma linha
+func (r *ReleaseAnalysis) summary() string {
+	text := fmt.Sprintf("%d publicados · %d major · %d minor · %d patch · %d pré-releases",
+		r.Published, r.Major, r.Minor, r.Patch, r.Prerelease)
+	if r.Drafts > 0 {
+		text += fmt.Sprintf(" · %d rascunhos", r.Drafts)
+	}
+	return text
+}
+
+// cadenceText descreve os intervalos entre releases
+func (r *ReleaseAnalysis) cadenceText() string {
+	if r.Intervals == 0 {
+		return "menos de dois releases publicados"
+	}
+	text := fmt.Sprintf("média de %.1f dias · mediana de %.1f dias", r.MeanIntervalDays, r.MedianIntervalDays)
+	if r.Cadence == CadenceInsufficient {
+		return text + " · regularidade: poucos releases para avaliar"
+	}
+	return text + fmt.Sprintf(" · %s (CV %.2f)", r.Cadence, r.IntervalCV)
+}
+
+func (r *ReleaseAnalysis) sectionTitle() string { return "🏷️ Versionamento e cadência" }
+
+// sectionLines resume versionamento, tipos de release e cadência
+func (r *ReleaseAnalysis) sectionLines() [][2]string {
+	lines := [][2]string{{"Resumo", r.summary()}}
+	if r.Latest != "" {
+		lines = append(lines, [2]string{"Maior versão estável", r.Latest})
+	}
+	if r.LastStableTag != "" {
+		lines = append(lines, [2]string{"Último release estável",
+			fmt.Sprintf("%s, há %d dias", r.LastStableTag, r.DaysSinceStable)})
+	} else {
+		lines = append(lines, [2]string{"Último release estável", "nenhum"})
+	}
+	lines = append(lines, [2]string{"Intervalo entre releases", r.cadenceText()})
+	if len(r.Breaking) > 0 {
+		lines = append(lines, [2]string{"Quebras de compatibilidade", strings.Join(r.Breaking, ", ")})
+	}
+	if len(r.NonSemver) > 0 {
+		lines = append(lines, [2]string{"Tags fora do semver", strings.Join(r.NonSemver, ", ")})
+	}
+	return lines
+}
+
+// htmlChart mostra os releases por tipo de mudança de versão
+func (r *ReleaseAnalysis) htmlChart() string {
+	if r.Semver <= 1 {
+		return ""
+	}
+	return barChartSVG([]chartItem{
+		{Label: ReleaseMajor, Value: float64(r.Major)},
+		{Label: ReleaseMinor, Value: float64(r.Minor)},
+		{Label: ReleasePatch, Value: float64(r.Patch)},
+		{Label: ReleasePrerelease, Value: float64(r.Prerelease)},
+	})
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+func TestAnalyzeReleases(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name       string
+		releases   []*extractor.ReleaseData
+		kinds      map[string]string
+		counts     [4]int // major, minor, patch, prerelease
+		breaking   []string
+		latest     string
+		lastStable string
+		days       int
+	}{
+		{
+			name: "major, minor e patch",
+			releases: []*extractor.ReleaseData{
+				{TagName: "v2.0.0", PublishedAt: daysAgo(1)},
+				{TagName: "v1.1.1", PublishedAt: daysAgo(10)},
+				{TagName: "v1.1.0", PublishedAt: daysAgo(20)},
+				{TagName: "v1.0.0", PublishedAt: daysAgo(30)},
+			},
+			kinds:      map[string]string{"v2.0.0": ReleaseMajor, "v1.1.1": ReleasePatch, "v1.1.0": ReleaseMinor, "v1.0.0": ReleaseInitial},
+			counts:     [4]int{1, 1, 1, 0},
+			breaking:   []string{"v2.0.0"},
+			latest:     "v2.0.0",
+			lastStable: "v2.0.0",
+			days:       1,
+		},
+		{
+			name: "minors em 0.x quebram compatibilidade",
+			releases: []*extractor.ReleaseData{
+				{TagName: "v0.3.0", PublishedAt: daysAgo(2)},
+				{TagName: "v0.2.1", PublishedAt: daysAgo(5)},
+				{TagName: "v0.2.0", PublishedAt: daysAgo(8)},
+				{TagName: "v0.1.0", PublishedAt: daysAgo(12)},
+			},
+			kinds:      map[string]string{"v0.3.0": ReleaseMinor, "v0.2.1": ReleasePatch, "v0.2.0": ReleaseMinor, "v0.1.0": ReleaseInitial},
+			counts:     [4]int{0, 2, 1, 0},
+			breaking:   []string{"v0.3.0", "v0.2.0"},
+			latest:     "v0.3.0",
+			lastStable: "v0.3.0",
+			days:       2,
+		},
+		{
+			name: "pré-releases e rascunhos não contam como estáveis",
+			releases: []*extractor.ReleaseData{
+				{TagName: "v1.2.0", PublishedAt: daysAgo(1), Draft: true},
+				{TagName: "v1.1.1", PublishedAt: daysAgo(3), Prerelease: true},
+				{TagName: "v1.1.0-rc.1", PublishedAt: daysAgo(5)},
+				{TagName: "v1.0.0", PublishedAt: daysAgo(40)},
+			},
+			kinds:      map[string]string{"v1.1.1": ReleasePrerelease, "v1.1.0-rc.1": ReleasePrerelease, "v1.0.0": ReleaseInitial},
+			counts:     [4]int{0, 0, 0, 2},
+			latest:     "v1.0.0",
+			lastStable: "v1.0.0",
+			days:       40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeReleases(&extractor.RepositoryData{Releases: tt.releases})
+
+			kinds := make(map[string]string)
+			for _, version := range analysis.Versions {
+				kinds[version.Tag] = version.Kind
+			}
+			for tag, want := range tt.kinds {
+				if kinds[tag] != want {
+					t.Errorf("tipo de %s = %q, esperado %q", tag, kinds[tag], want)
+				}
+			}
+			if len(kinds) != len(tt.kinds) {
+				t.Errorf("versões = %v, esperado %v", kinds, tt.kinds)
+			}
+			if counts := [4]int{analysis.Major, analysis.Minor, analysis.Patch, analysis.Prerelease}; counts != tt.counts {
+				t.Errorf("contagens = %v, esperado %v", counts, tt.counts)
+			}
+			if !slices.Equal(analysis.Breaking, tt.breaking) {
+				t.Errorf("Breaking = %v, esperado %v", analysis.Breaking, tt.breaking)
+			}
+			if analysis.Latest != tt.latest {
+				t.Errorf("Latest = %q, esperado %q", analysis.Latest, tt.latest)
+			}
+			if analysis.LastStableTag != tt.lastStable || analysis.DaysSinceStable != tt.days {
+				t.Errorf("último estável = %q há %d dias, esperado %q há %d dias",
+					analysis.LastStableTag, analysis.DaysSinceStable, tt.lastStable, tt.days)
+			}
+		})
+	}
+
+	if AnalyzeReleases(&extractor.RepositoryData{}) != nil {
+		t.Error("sem releases a análise deveria ser nil")
+	}
+}
+
+func TestMeasureCadence(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		days      []int
+		intervals int
+		mean      float64
+		median    float64
+		cv        float64
+		cadence   string
+	}{
+		{"um release", []int{0}, 0, 0, 0, 0, CadenceInsufficient},
+		{"dois intervalos", []int{0, 10, 30}, 2, 15, 15, 0.33, CadenceInsufficient},
+		{"intervalos iguais", []int{30, 0, 20, 10}, 3, 10, 10, 0, CadenceRegular},
+		{"intervalos variáveis", []int{0, 10, 40, 50}, 3, 16.7, 10, 0.57, CadenceVariable},
+		{"intervalos irregulares", []int{0, 1, 2, 3, 60}, 4, 15, 1, 1.62, CadenceIrregular},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates := make([]time.Time, len(tt.days))
+			for i, day := range tt.days {
+				dates[i] = start.AddDate(0, 0, day)
+			}
+			analysis := &ReleaseAnalysis{Cadence: CadenceInsufficient}
+			analysis.measureCadence(dates)
+
+			if analysis.Intervals != tt.intervals {
+				t.Errorf("Intervals = %d, esperado %d", analysis.Intervals, tt.intervals)
+			}
+			if analysis.MeanIntervalDays != tt.mean || analysis.MedianIntervalDays != tt.median {
+				t.Errorf("média %v e mediana %v, esperado %v e %v",
+					analysis.MeanIntervalDays, analysis.MedianIntervalDays, tt.mean, tt.median)
+			}
+			if analysis.IntervalCV != tt.cv {
+				t.Errorf("IntervalCV = %v, esperado %v", analysis.IntervalCV, tt.cv)
+			}
+			if analysis.Cadence != tt.cadence {
+				t.Errorf("Cadence = %q, esperado %q", analysis.Cadence, tt.cadence)
+			}
+		})
+	}
+}
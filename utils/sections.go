@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// reportSection é uma seção de indicadores dos relatórios. As análises que a
+// implementam aparecem nos relatórios txt, md e html pelo mesmo código, sem
+// mudanças nos renderers a cada nova análise.
+type reportSection interface {
+	// sectionTitle retorna o emoji e o título da seção
+	sectionTitle() string
+	// sectionLines retorna os indicadores como pares rótulo/valor; sem
+	// indicadores a seção não é exibida
+	sectionLines() [][2]string
+}
+
+// Extensões opcionais de uma seção, exibidas após os indicadores
+type (
+	textDetailer interface {
+		textDetails() []string
+	}
+	markdownDetailer interface {
+		markdownDetails() string
+	}
+	htmlDetailer interface {
+		htmlDetails() string
+	}
+	// htmlCharter exibe um gráfico antes dos indicadores no relatório HTML
+	htmlCharter interface {
+		htmlChart() string
+	}
+)
+
+// sections retorna as seções de indicadores presentes na análise, na ordem
+// em que aparecem nos relatórios
+func (a *Analysis) sections() []reportSection {
+	var sections []reportSection
+	if a.BusFactor != nil {
+		sections = append(sections, a.BusFactor)
+	}
+	if a.Community != nil {
+		sections = append(sections, a.Community)
+	}
+	if a.CodeOwners != nil {
+		sections = append(sections, a.CodeOwners)
+	}
+	if a.Stats != nil {
+		sections = append(sections, a.Stats)
+	}
+	if a.Tree != nil {
+		sections = append(sections, a.Tree)
+	}
+	if a.Dependencies != nil {
+		sections = append(sections, a.Dependencies)
+	}
+	if a.Security != nil {
+		sections = append(sections, a.Security)
+	}
+	if a.Releases != nil {
+		sections = append(sections, a.Releases)
+	}
+	if a.Downloads != nil {
+		sections = append(sections, a.Downloads)
+	}
+	if a.Commits != nil {
+		sections = append(sections, a.Commits)
+	}
+	return sections
+}
+
+// writeTextSections escreve as seções no relatório em texto
+func writeTextSections(report *strings.Builder, sections []reportSection) {
+	for _, section := range sections {
+		lines := section.sectionLines()
+		if len(lines) == 0 {
+			continue
+		}
+
+		report.WriteString(strings.ToUpper(section.sectionTitle()) + "\n")
+		report.WriteString(strings.Repeat("-", 40) + "\n")
+		for _, line := range lines {
+			report.WriteString(fmt.Sprintf("%s: %s\n", line[0], line[1]))
+		}
+		if details, ok := section.(textDetailer); ok {
+			for _, detail := range details.textDetails() {
+				report.WriteString(detail + "\n")
+			}
+		}
+		report.WriteString("\n")
+	}
+}
+
+// writeMarkdownSections escreve as seções no relatório Markdown
+func writeMarkdownSections(md *strings.Builder, sections []reportSection) {
+	for _, section := range sections {
+		lines := section.sectionLines()
+		if len(lines) == 0 {
+			continue
+		}
+
+		md.WriteString("## " + section.sectionTitle() + "\n\n")
+		md.WriteString("| Indicador | Valor |\n|---|---|\n")
+		for _, line := range lines {
+			md.WriteString(fmt.Sprintf("| %s | %s |\n", line[0], escapeMarkdownCell(line[1])))
+		}
+		md.WriteString("\n")
+		if details, ok := section.(markdownDetailer); ok {
+			md.WriteString(details.markdownDetails())
+		}
+	}
+}
+
+// writeHTMLSections escreve as seções no relatório HTML
+func writeHTMLSections(page *strings.Builder, sections []reportSection) {
+	for _, section := range sections {
+		lines := section.sectionLines()
+		if len(lines) == 0 {
+			continue
+		}
+
+		page.WriteString("<section><h2>" + html.EscapeString(section.sectionTitle()) + "</h2>")
+		if chart, ok := section.(htmlCharter); ok {
+			page.WriteString(chart.htmlChart())
+		}
+		page.WriteString("<table>")
+		for _, line := range lines {
+			page.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td></tr>", html.EscapeString(line[0]), html.EscapeString(line[1])))
+		}
+		page.WriteString("</table>")
+		if details, ok := section.(htmlDetailer); ok {
+			page.WriteString(details.htmlDetails())
+		}
+		page.WriteString("</section>\n")
+	}
+}
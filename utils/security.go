@@ -0,0 +1,334 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// securitySeverityPenalty são os pontos retirados do score de segurança por
+// alerta aberto de cada severidade
+var securitySeverityPenalty = map[string]float64{
+	extractor.SeverityCritical: 25,
+	extractor.SeverityHigh:     10,
+	extractor.SeverityMedium:   3,
+	extractor.SeverityLow:      1,
+}
+
+// securityStaleDays é a idade a partir da qual a penalidade de um alerta dobra
+const securityStaleDays = 90
+
+// securityDisabledPenalty é retirado do score por recurso de segurança desativado
+const securityDisabledPenalty = 10
+
+// securityTopAlerts é o número de alertas detalhados nos relatórios
+const securityTopAlerts = 10
+
+// securityAgeBuckets são as faixas de idade dos alertas abertos (maxDays 0:
+// sem limite)
+var securityAgeBuckets = []struct {
+	label   string
+	maxDays int
+}{
+	{"até 7 dias", 7},
+	{"8 a 30 dias", 30},
+	{"31 a 90 dias", 90},
+	{"mais de 90 dias", 0},
+}
+
+// securityFeatureLabels são os nomes de exibição dos recursos de segurança
+var securityFeatureLabels = map[string]string{
+	extractor.SecurityDependabot:     "Dependabot",
+	extractor.SecurityCodeScanning:   "Code scanning",
+	extractor.SecuritySecretScanning: "Secret scanning",
+}
+
+// SecurityAnalysis resume a postura de segurança do repositório
+type SecurityAnalysis struct {
+	Features   []*SecurityFeatureSummary `json:"features"`
+	Open       int                       `json:"open"`
+	BySeverity []*SeverityCount          `json:"by_severity"`
+	ByAge      []*AgeBucketCount         `json:"by_age"`
+	OldestDays int                       `json:"oldest_days"`
+	// TopAlerts são os alertas mais graves e, na mesma severidade, os mais antigos
+	TopAlerts []*extractor.SecurityAlert `json:"top_alerts"`
+	// Score parte de 100 e perde pontos por alerta aberto (em dobro após
+	// securityStaleDays dias) e por recurso desativado
+	Score float64 `json:"score"`
+	// Scored indica que ao menos um recurso pôde ser lido; sem nenhum, o score
+	// fica em 100 e não reflete a postura real
+	Scored bool `json:"scored"`
+	// Complete indica que todos os recursos foram lidos por inteiro
+	Complete bool `json:"complete"`
+}
+
+// SecurityFeatureSummary é a situação de um recurso e seus alertas abertos
+type SecurityFeatureSummary struct {
+	Feature   string `json:"feature"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	Open      int    `json:"open"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// SeverityCount conta os alertas abertos de uma severidade
+type SeverityCount struct {
+	Severity string `json:"severity"`
+	Count    int    `json:"count"`
+}
+
+// AgeBucketCount conta os alertas abertos de uma faixa de idade
+type AgeBucketCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// AnalyzeSecurity conta os alertas abertos por recurso, severidade e idade e
+// calcula o score de segurança; retorna nil quando os alertas não foram extraídos
+func AnalyzeSecurity(data *extractor.RepositoryData) *SecurityAnalysis {
+	security := data.Security
+	if security == nil {
+		return nil
+	}
+
+	now := time.Now()
+	analysis := &SecurityAnalysis{Open: len(security.Alerts), Score: 100, Complete: true}
+
+	openByFeature := make(map[string]int)
+	bySeverity := make(map[string]int)
+	ages := make([]int, len(securityAgeBuckets))
+	for _, alert := range security.Alerts {
+		openByFeature[alert.Feature]++
+		bySeverity[alert.Severity]++
+
+		days := int(now.Sub(alert.CreatedAt).Hours() / 24)
+		if days > analysis.OldestDays {
+			analysis.OldestDays = days
+		}
+		for i, bucket := range securityAgeBuckets {
+			if bucket.maxDays == 0 || days <= bucket.maxDays {
+				ages[i]++
+				break
+			}
+		}
+
+		penalty := securitySeverityPenalty[alert.Severity]
+		if days > securityStaleDays {
+			penalty *= 2
+		}
+		analysis.Score -= penalty
+	}
+
+	for _, feature := range extractor.SecurityFeatureOrder {
+		status, ok := security.Features[feature]
+		if !ok {
+			continue
+		}
+		analysis.Features = append(analysis.Features, &SecurityFeatureSummary{
+			Feature:   feature,
+			Status:    status.Status,
+			Message:   status.Message,
+			Open:      openByFeature[feature],
+			Truncated: status.Truncated,
+		})
+		switch status.Status {
+		case extractor.SecurityEnabled:
+			analysis.Scored = true
+			if status.Truncated {
+				analysis.Complete = false
+			}
+		case extractor.SecurityDisabled:
+			analysis.Scored = true
+			analysis.Score -= securityDisabledPenalty
+		default:
+			analysis.Complete = false
+		}
+	}
+	analysis.Score = math.Max(0, analysis.Score)
+
+	for _, severity := range extractor.SeverityOrder {
+		analysis.BySeverity = append(analysis.BySeverity, &SeverityCount{Severity: severity, Count: bySeverity[severity]})
+	}
+	for i, bucket := range securityAgeBuckets {
+		analysis.ByAge = append(analysis.ByAge, &AgeBucketCount{Label: bucket.label, Count: ages[i]})
+	}
+
+	rank := make(map[string]int)
+	for i, severity := range extractor.SeverityOrder {
+		rank[severity] = i
+	}
+	top := make([]*extractor.SecurityAlert, len(security.Alerts))
+	copy(top, security.Alerts)
+	sort.SliceStable(top, func(i, j int) bool {
+		if rank[top[i].Severity] != rank[top[j].Severity] {
+			return rank[top[i].Severity] < rank[top[j].Severity]
+		}
+		return top[i].CreatedAt.Before(top[j].CreatedAt)
+	})
+	if len(top) > securityTopAlerts {
+		top = top[:securityTopAlerts]
+	}
+	analysis.TopAlerts = top
+
+	return analysis
+}
+
+// Count retorna os alertas abertos de uma severidade
+func (s *SecurityAnalysis) Count(severity string) int {
+	for _, count := range s.BySeverity {
+		if count.Severity == severity {
+			return count.Count
+		}
+	}
+	return 0
+}
+
+// securityBasis descreve os sinais de segurança: sem recursos legíveis o valor
+// é apenas o padrão, e com recursos sem permissão ou truncados ele é parcial
+func securityBasis(_ *extractor.RepositoryData, a *Analysis) *SignalBasis {
+	security := a.Security
+	switch {
+	case security == nil || !security.Scored:
+		return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+	case !security.Complete:
+		return &SignalBasis{Source: SourceAPI, SampleSize: security.Open, Confidence: ConfidenceMedium}
+	}
+	return &SignalBasis{Source: SourceAPI, SampleSize: security.Open, Confidence: ConfidenceHigh}
+}
+
+// securityFeatureText descreve a situação de um recurso
+func securityFeatureText(feature *SecurityFeatureSummary) string {
+	switch feature.Status {
+	case extractor.SecurityEnabled:
+		text := fmt.Sprintf("✅ %d alertas abertos", feature.Open)
+		if feature.Truncated {
+			text += " (lista truncada)"
+		}
+		return text
+	case extractor.SecurityDisabled:
+		return "❌ desativado"
+	case extractor.SecurityForbidden:
+		return "🔒 sem permissão (" + feature.Message + ")"
+	}
+	return "⚠️ erro (" + feature.Message + ")"
+}
+
+// severityText formata a contagem por severidade
+func (s *SecurityAnalysis) severityText() string {
+	parts := make([]string, len(s.BySeverity))
+	for i, count := range s.BySeverity {
+		parts[i] = fmt.Sprintf("%s: %d", count.Severity, count.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ageText formata a contagem por faixa de idade
+func (s *SecurityAnalysis) ageText() string {
+	parts := make([]string, len(s.ByAge))
+	for i, bucket := range s.ByAge {
+		parts[i] = fmt.Sprintf("%s: %d", bucket.Label, bucket.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// scoreText formata o score indicando quando ele não reflete a postura real
+func (s *SecurityAnalysis) scoreText() string {
+	switch {
+	case !s.Scored:
+		return "indisponível (nenhum recurso pôde ser lido)"
+	case !s.Complete:
+		return fmt.Sprintf("%.0f/100 (parcial)", s.Score)
+	}
+	return fmt.Sprintf("%.0f/100", s.Score)
+}
+
+func (s *SecurityAnalysis) sectionTitle() string { return "🔒 Segurança" }
+
+// sectionLines resume o score e os alertas abertos por recurso, severidade e idade
+func (s *SecurityAnalysis) sectionLines() [][2]string {
+	lines := [][2]string{{"Score de segurança", s.scoreText()}}
+	for _, feature := range s.Features {
+		lines = append(lines, [2]string{securityFeatureLabels[feature.Feature], securityFeatureText(feature)})
+	}
+	if s.Open > 0 {
+		lines = append(lines,
+			[2]string{"Por severidade", s.severityText()},
+			[2]string{"Por idade", s.ageText()},
+			[2]string{"Mais antigo", fmt.Sprintf("%d dias", s.OldestDays)},
+		)
+	}
+	return lines
+}
+
+// securityAlertText descreve um alerta em uma linha
+func securityAlertText(alert *extractor.SecurityAlert) string {
+	text := fmt.Sprintf("[%s] %s #%d: %s", alert.Severity, securityFeatureLabels[alert.Feature], alert.Number, alert.Title)
+	if alert.Location != "" && alert.Location != alert.Title {
+		text += " (" + alert.Location + ")"
+	}
+	return text + fmt.Sprintf(" · aberto em %s", alert.CreatedAt.Format("02/01/2006"))
+}
+
+// htmlChart mostra os alertas abertos por severidade
+func (s *SecurityAnalysis) htmlChart() string {
+	if s.Open == 0 {
+		return ""
+	}
+
+	var items []chartItem
+	for _, count := range s.BySeverity {
+		items = append(items, chartItem{Label: count.Severity, Value: float64(count.Count)})
+	}
+	return barChartSVG(items)
+}
+
+// textDetails lista os alertas mais graves
+func (s *SecurityAnalysis) textDetails() []string {
+	var details []string
+	for _, alert := range s.TopAlerts {
+		details = append(details, "⚠️ "+securityAlertText(alert))
+	}
+	return details
+}
+
+// markdownDetails lista os alertas mais graves em tabela
+func (s *SecurityAnalysis) markdownDetails() string {
+	if len(s.TopAlerts) == 0 {
+		return ""
+	}
+
+	var md strings.Builder
+	md.WriteString("| Severidade | Recurso | Alerta | Local | Aberto em |\n|---|---|---|---|---|\n")
+	for _, alert := range s.TopAlerts {
+		md.WriteString(fmt.Sprintf("| %s | %s | [#%d](%s) %s | %s | %s |\n",
+			alert.Severity, securityFeatureLabels[alert.Feature],
+			alert.Number, alert.URL, escapeMarkdownCell(alert.Title),
+			escapeMarkdownCell(valueOrDash(alert.Location)),
+			alert.CreatedAt.Format("02/01/2006")))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
+
+// htmlDetails lista os alertas mais graves em tabela
+func (s *SecurityAnalysis) htmlDetails() string {
+	if len(s.TopAlerts) == 0 {
+		return ""
+	}
+
+	var page strings.Builder
+	page.WriteString("<table><tr><th>Severidade</th><th>Alerta</th><th>Aberto em</th></tr>")
+	for _, alert := range s.TopAlerts {
+		page.WriteString(fmt.Sprintf("<tr><td>%s</td><td><a href=\"%s\">%s #%d</a> %s</td><td>%s</td></tr>",
+			html.EscapeString(alert.Severity), html.EscapeString(alert.URL),
+			html.EscapeString(securityFeatureLabels[alert.Feature]), alert.Number,
+			html.EscapeString(alert.Title), alert.CreatedAt.Format("02/01/2006")))
+	}
+	page.WriteString("</table>")
+	return page.String()
+}
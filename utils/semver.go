@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverPattern reconhece versões semânticas em tags, com prefixo opcional
+// ("v1.2.3", "release-1.2.3", "api/v1.2.3") e patch opcional ("v1.2")
+var semverPattern = regexp.MustCompile(`^(?:.*?[/_-])??[vV]?(\d+)\.(\d+)(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// SemVer é uma versão semântica extraída de uma tag
+type SemVer struct {
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	Prerelease string `json:"prerelease,omitempty"`
+}
+
+// ParseSemVer interpreta a tag como versão semântica; metadados de build
+// ("+build.1") são ignorados, como pede a especificação
+func ParseSemVer(tag string) (*SemVer, bool) {
+	match := semverPattern.FindStringSubmatch(strings.TrimSpace(tag))
+	if match == nil {
+		return nil, false
+	}
+
+	version := &SemVer{Prerelease: match[4]}
+	version.Major, _ = strconv.Atoi(match[1])
+	version.Minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		version.Patch, _ = strconv.Atoi(match[3])
+	}
+	return version, true
+}
+
+// String formata a versão sem prefixo (ex: "1.2.3-rc.1")
+func (v *SemVer) String() string {
+	text := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		text += "-" + v.Prerelease
+	}
+	return text
+}
+
+// Compare retorna -1, 0 ou 1 conforme a versão seja menor, igual ou maior que
+// a outra, com a precedência de pré-releases da especificação
+func (v *SemVer) Compare(other *SemVer) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return compareInts(pair[0], pair[1])
+		}
+	}
+
+	// Sem pré-release a versão tem precedência maior (1.0.0 > 1.0.0-rc.1)
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		na, errA := strconv.Atoi(a[i])
+		nb, errB := strconv.Atoi(b[i])
+		switch {
+		case errA == nil && errB == nil:
+			return compareInts(na, nb)
+		case errA == nil:
+			return -1 // Identificadores numéricos têm precedência menor
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(a[i], b[i])
+	}
+	return compareInts(len(a), len(b))
+}
+
+// compareInts retorna -1, 0 ou 1 conforme a seja menor, igual ou maior que b
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
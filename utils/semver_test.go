@@ -0,0 +1,83 @@
+package utils
+
+import "testing"
+
+func TestParseSemVer(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{"v1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.3", true},
+		{"V2.0.0", "2.0.0", true},
+		{"v1.2", "1.2.0", true},
+		{"  v1.2.3  ", "1.2.3", true},
+		{"v1.0.0-rc.1", "1.0.0-rc.1", true},
+		{"v1.0.0-alpha-2", "1.0.0-alpha-2", true},
+		{"v1.0.0+build.5", "1.0.0", true},
+		{"v1.0.0-beta+exp.sha.5114f85", "1.0.0-beta", true},
+		{"release-1.4.0", "1.4.0", true},
+		{"api/v0.3.1", "0.3.1", true},
+		{"my_tool_v3.1.0", "3.1.0", true},
+		{"v10.20.30", "10.20.30", true},
+		{"v1", "", false},
+		{"latest", "", false},
+		{"nightly-2024-01-01", "", false},
+		{"v1.2.3.4", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		version, ok := ParseSemVer(tt.tag)
+		if ok != tt.ok {
+			t.Errorf("ParseSemVer(%q) ok = %v, esperado %v", tt.tag, ok, tt.ok)
+			continue
+		}
+		if ok && version.String() != tt.want {
+			t.Errorf("ParseSemVer(%q) = %s, esperado %s", tt.tag, version, tt.want)
+		}
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.0.0", "1.0.0+build.1", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"2.1.0", "2.0.9", 1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.10", "1.0.2", 1},
+		{"1.2", "1.2.0", 0},
+
+		// Precedência de pré-releases da especificação (item 11)
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0-rc.1", 0},
+		{"1.0.0-rc.2", "1.0.0-rc.1", 1},
+		{"1.0.1-alpha", "1.0.0", 1},
+	}
+
+	for _, tt := range tests {
+		a, okA := ParseSemVer(tt.a)
+		b, okB := ParseSemVer(tt.b)
+		if !okA || !okB {
+			t.Fatalf("versões inválidas no caso %q x %q", tt.a, tt.b)
+		}
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, esperado %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.Compare(a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, esperado %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+// Origens possíveis do valor de um sinal de saúde
+const (
+	// SourceAPI indica um valor exato retornado pela API do repositório
+	SourceAPI = "api"
+	// SourceSearch indica uma contagem completa obtida pela Search API
+	SourceSearch = "search"
+	// SourceSample indica um valor estimado a partir das listas recentes
+	SourceSample = "sample"
+)
+
+// Níveis de confiança de um sinal
+const (
+	ConfidenceHigh   = "alta"
+	ConfidenceMedium = "média"
+	ConfidenceLow    = "baixa"
+)
+
+// SignalBasis descreve de onde vem o valor de um sinal, quantos itens foram
+// considerados e quanto se pode confiar nele
+type SignalBasis struct {
+	Source        string  `json:"source"`
+	SampleSize    int     `json:"sample_size"`
+	MarginOfError float64 `json:"margin_of_error,omitempty"`
+	Confidence    string  `json:"confidence"`
+	// Fallback explica por que a fonte preferida não foi usada
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// String descreve a base do sinal em uma linha
+func (b *SignalBasis) String() string {
+	if b.Fallback != "" {
+		return b.sourceText() + " · contagem completa indisponível: " + b.Fallback
+	}
+	return b.sourceText()
+}
+
+// sourceText descreve a origem, o tamanho e a confiança do sinal
+func (b *SignalBasis) sourceText() string {
+	switch b.Source {
+	case SourceSearch:
+		return fmt.Sprintf("contagem completa de %d issues · confiança %s", b.SampleSize, b.Confidence)
+	case SourceSample:
+		if b.MarginOfError > 0 {
+			return fmt.Sprintf("amostra de %d itens recentes · confiança %s (±%.0f%%)",
+				b.SampleSize, b.Confidence, b.MarginOfError*100)
+		}
+		return fmt.Sprintf("amostra de %d itens recentes · confiança %s", b.SampleSize, b.Confidence)
+	default:
+		if b.SampleSize > 0 {
+			return fmt.Sprintf("valor da API (%d itens) · confiança %s", b.SampleSize, b.Confidence)
+		}
+		if b.Confidence == ConfidenceLow {
+			return "nenhum item retornado pela API · confiança baixa"
+		}
+		return "valor da API · confiança " + b.Confidence
+	}
+}
+
+// exactBasis é a base dos valores lidos diretamente do repositório
+var exactBasis = &SignalBasis{Source: SourceAPI, Confidence: ConfidenceHigh}
+
+// searchBasis descreve sinais calculados com as contagens da Search API; com
+// resultados incompletos (timeout da busca) a confiança cai para média
+func searchBasis(counts *extractor.IssueCounts) *SignalBasis {
+	confidence := ConfidenceHigh
+	if counts.Incomplete {
+		confidence = ConfidenceMedium
+	}
+	return &SignalBasis{Source: SourceSearch, SampleSize: counts.Total(), Confidence: confidence}
+}
+
+// sampleProportionBasis descreve uma proporção estimada a partir de n itens,
+// com a margem de erro de 95% no pior caso (p = 0.5). As listas recentes são
+// ordenadas por atualização, não sorteadas, então a confiança nunca é alta.
+func sampleProportionBasis(n int) *SignalBasis {
+	if n == 0 {
+		return &SignalBasis{Source: SourceSample, Confidence: ConfidenceLow}
+	}
+
+	margin := 1.96 * 0.5 / math.Sqrt(float64(n))
+	confidence := ConfidenceLow
+	if margin <= 0.1 {
+		confidence = ConfidenceMedium
+	}
+	return &SignalBasis{Source: SourceSample, SampleSize: n, MarginOfError: margin, Confidence: confidence}
+}
+
+// withFallback registra na base o motivo de a fonte preferida não ter sido usada
+func withFallback(basis *SignalBasis, reason string) *SignalBasis {
+	basis.Fallback = reason
+	return basis
+}
+
+// sampleCountBasis descreve contagens feitas dentro de uma amostra: o total
+// real pode ser maior do que o tamanho da amostra permite observar
+func sampleCountBasis(n int) *SignalBasis {
+	return &SignalBasis{Source: SourceSample, SampleSize: n, Confidence: ConfidenceLow}
+}
+
+// latestBasis descreve sinais que dependem apenas do item mais recente de uma
+// lista (ex: último commit): exatos sempre que a lista não está vazia
+func latestBasis(n int) *SignalBasis {
+	if n == 0 {
+		return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+	}
+	return &SignalBasis{Source: SourceAPI, SampleSize: n, Confidence: ConfidenceHigh}
+}
+
+// windowBasis descreve contagens em uma janela de tempo a partir de uma lista
+// limitada a limit itens: a contagem é completa quando a lista não foi
+// truncada ou quando o item mais antigo já está fora da janela
+func windowBasis(times []time.Time, limit int, since time.Time) *SignalBasis {
+	n := len(times)
+	if n < limit {
+		return &SignalBasis{Source: SourceAPI, SampleSize: n, Confidence: ConfidenceHigh}
+	}
+	for _, t := range times {
+		if t.Before(since) {
+			return &SignalBasis{Source: SourceAPI, SampleSize: n, Confidence: ConfidenceHigh}
+		}
+	}
+	return sampleCountBasis(n)
+}
+
+// commitTimes retorna as datas dos commits recentes
+func commitTimes(data *extractor.RepositoryData) []time.Time {
+	times := make([]time.Time, len(data.RecentCommits))
+	for i, commit := range data.RecentCommits {
+		times[i] = commit.CreatedAt
+	}
+	return times
+}
+
+// listBasis descreve contagens sobre uma lista limitada a limit itens: abaixo
+// do limite a lista está completa, no limite ela pode ter sido truncada
+func listBasis(n, limit int) *SignalBasis {
+	if n < limit {
+		return &SignalBasis{Source: SourceAPI, SampleSize: n, Confidence: ConfidenceHigh}
+	}
+	return sampleCountBasis(n)
+}
+
+// busFactorBasis descreve as métricas de concentração: exatas com a atividade
+// semanal (até 100 colaboradores), amostrais com a lista de colaboradores
+func busFactorBasis(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+	if len(d.ContributorActivity) > 0 {
+		return listBasis(len(d.ContributorActivity), 100)
+	}
+	return sampleCountBasis(len(d.Contributors))
+}
+
+// treeBasis descreve sinais calculados sobre a árvore de arquivos, que pode
+// ter ficado incompleta em repositórios muito grandes
+func treeBasis(d *extractor.RepositoryData, _ *Analysis) *SignalBasis {
+	switch {
+	case d.Tree == nil:
+		return &SignalBasis{Source: SourceAPI, Confidence: ConfidenceLow}
+	case d.Tree.Truncated:
+		return &SignalBasis{Source: SourceAPI, SampleSize: len(d.Tree.Files), Confidence: ConfidenceMedium}
+	}
+	return exactBasis
+}
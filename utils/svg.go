@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+// chartPalette define as cores usadas nos gráficos SVG
+var chartPalette = []string{
+	"#0969da", "#1a7f37", "#bf8700", "#cf222e", "#8250df",
+	"#1b7c83", "#bc4c00", "#6e7781", "#a475f9", "#2da44e",
+}
+
+// chartItem representa um valor rotulado em um gráfico
+type chartItem struct {
+	Label string
+	Value float64
+}
+
+// pieChartSVG gera um gráfico de pizza (donut) com legenda
+func pieChartSVG(items []chartItem) string {
+	total := 0.0
+	for _, item := range items {
+		total += item.Value
+	}
+	if total == 0 {
+		return emptyChartSVG()
+	}
+
+	const cx, cy, r, inner = 110.0, 110.0, 100.0, 55.0
+	height := math.Max(220, float64(len(items))*22+20)
+
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 420 %.0f" role="img">`, height))
+
+	angle := -math.Pi / 2
+	for i, item := range items {
+		color := chartPalette[i%len(chartPalette)]
+		fraction := item.Value / total
+
+		// Um único item ocupa o círculo inteiro
+		if fraction >= 0.9999 {
+			svg.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`, cx, cy, r, color))
+		} else if fraction > 0 {
+			end := angle + fraction*2*math.Pi
+			largeArc := 0
+			if fraction > 0.5 {
+				largeArc = 1
+			}
+			svg.WriteString(fmt.Sprintf(`<path d="M %.2f %.2f L %.2f %.2f A %.1f %.1f 0 %d 1 %.2f %.2f Z" fill="%s"><title>%s</title></path>`,
+				cx, cy,
+				cx+r*math.Cos(angle), cy+r*math.Sin(angle),
+				r, r, largeArc,
+				cx+r*math.Cos(end), cy+r*math.Sin(end),
+				color, html.EscapeString(fmt.Sprintf("%s: %.1f%%", item.Label, fraction*100))))
+			angle = end
+		}
+
+		svg.WriteString(fmt.Sprintf(`<rect x="240" y="%d" width="12" height="12" fill="%s"/>`, 14+i*22, color))
+		svg.WriteString(fmt.Sprintf(`<text x="258" y="%d" font-size="12">%s (%.1f%%)</text>`,
+			24+i*22, html.EscapeString(item.Label), fraction*100))
+	}
+	svg.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="#fff"/>`, cx, cy, inner))
+	svg.WriteString("</svg>")
+
+	return svg.String()
+}
+
+// barChartSVG gera um gráfico de barras verticais
+func barChartSVG(items []chartItem) string {
+	if len(items) == 0 {
+		return emptyChartSVG()
+	}
+
+	max := 0.0
+	for _, item := range items {
+		max = math.Max(max, item.Value)
+	}
+
+	const height, chartTop, chartBottom, barWidth, gap = 220.0, 20.0, 180.0, 48.0, 24.0
+	width := float64(len(items))*(barWidth+gap) + gap
+
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.0f %.0f" role="img">`, width, height))
+	svg.WriteString(fmt.Sprintf(`<line x1="0" y1="%.0f" x2="%.0f" y2="%.0f" stroke="#d0d7de"/>`, chartBottom, width, chartBottom))
+
+	for i, item := range items {
+		barHeight := 0.0
+		if max > 0 {
+			barHeight = item.Value / max * (chartBottom - chartTop)
+		}
+		x := gap + float64(i)*(barWidth+gap)
+		y := chartBottom - barHeight
+
+		svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.0f" height="%.1f" rx="3" fill="%s"/>`,
+			x, y, barWidth, barHeight, chartPalette[i%len(chartPalette)]))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" font-size="12" text-anchor="middle">%s</text>`,
+			x+barWidth/2, y-4, formatChartValue(item.Value)))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.0f" font-size="11" text-anchor="middle">%s</text>`,
+			x+barWidth/2, chartBottom+16, html.EscapeString(item.Label)))
+	}
+	svg.WriteString("</svg>")
+
+	return svg.String()
+}
+
+// horizontalBarChartSVG gera um gráfico de barras horizontais, útil para rankings
+func horizontalBarChartSVG(items []chartItem) string {
+	if len(items) == 0 {
+		return emptyChartSVG()
+	}
+
+	max := 0.0
+	for _, item := range items {
+		max = math.Max(max, item.Value)
+	}
+
+	const labelWidth, barArea, rowHeight = 140.0, 300.0, 24.0
+	height := float64(len(items))*rowHeight + 8
+
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.0f %.0f" role="img">`, labelWidth+barArea+70, height))
+
+	for i, item := range items {
+		y := float64(i)*rowHeight + 4
+		barWidth := 0.0
+		if max > 0 {
+			barWidth = item.Value / max * barArea
+		}
+
+		svg.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.1f" font-size="12" text-anchor="end">%s</text>`,
+			labelWidth-8, y+14, html.EscapeString(item.Label)))
+		svg.WriteString(fmt.Sprintf(`<rect x="%.0f" y="%.1f" width="%.1f" height="16" rx="3" fill="%s"/>`,
+			labelWidth, y+2, barWidth, chartPalette[0]))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" font-size="12">%s</text>`,
+			labelWidth+barWidth+6, y+14, formatChartValue(item.Value)))
+	}
+	svg.WriteString("</svg>")
+
+	return svg.String()
+}
+
+// gaugeSVG gera um medidor semicircular para valores de 0 a 100
+func gaugeSVG(value float64, label string) string {
+	value = math.Max(0, math.Min(100, value))
+
+	const cx, cy, r = 120.0, 120.0, 90.0
+	color := "#1a7f37"
+	switch {
+	case value < 50:
+		color = "#cf222e"
+	case value < 70:
+		color = "#bf8700"
+	}
+
+	// Arco de 180° (esquerda → direita) proporcional ao valor
+	angle := math.Pi * (1 - value/100)
+	endX := cx + r*math.Cos(angle)
+	endY := cy - r*math.Sin(angle)
+
+	var svg strings.Builder
+	svg.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 240 150" role="img">`)
+	svg.WriteString(fmt.Sprintf(`<path d="M %.0f %.0f A %.0f %.0f 0 0 1 %.0f %.0f" fill="none" stroke="#eaeef2" stroke-width="18"/>`,
+		cx-r, cy, r, r, cx+r, cy))
+	if value > 0 {
+		svg.WriteString(fmt.Sprintf(`<path d="M %.0f %.0f A %.0f %.0f 0 0 1 %.2f %.2f" fill="none" stroke="%s" stroke-width="18"/>`,
+			cx-r, cy, r, r, endX, endY, color))
+	}
+	svg.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" font-size="32" font-weight="600" text-anchor="middle">%.0f</text>`, cx, cy-10, value))
+	svg.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" font-size="13" text-anchor="middle">%s</text>`, cx, cy+20, html.EscapeString(label)))
+	svg.WriteString("</svg>")
+
+	return svg.String()
+}
+
+// emptyChartSVG gera um placeholder para gráficos sem dados
+func emptyChartSVG() string {
+	return `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 300 60" role="img"><text x="150" y="35" font-size="13" text-anchor="middle" fill="#6e7781">Sem dados</text></svg>`
+}
+
+// formatChartValue formata valores numéricos exibidos nos gráficos
+func formatChartValue(value float64) string {
+	if value == math.Trunc(value) {
+		return formatNumber(int(value))
+	}
+	return fmt.Sprintf("%.1f", value)
+}
@@ -0,0 +1,405 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github-octokit-poc/extractor"
+)
+
+const (
+	// treeTopEntries limita extensões e diretórios listados nos relatórios
+	treeTopEntries = 10
+	// treeLargestFiles limita a lista de maiores arquivos
+	treeLargestFiles = 10
+	// treeUntestedSample limita os pacotes sem testes listados
+	treeUntestedSample = 15
+)
+
+// vendoredDirs são nomes de diretório com código de terceiros ou gerado
+var vendoredDirs = map[string]bool{
+	"vendor": true, "node_modules": true, "third_party": true, "third-party": true,
+	"bower_components": true, "Pods": true, "Carthage": true, ".yarn": true,
+	"dist": true, "generated": true, "__generated__": true,
+}
+
+// generatedSuffixes identificam arquivos gerados fora de diretórios conhecidos
+var generatedSuffixes = []string{
+	".min.js", ".min.css", ".pb.go", "_pb2.py", ".pb.cc", ".pb.h",
+	"_generated.go", ".generated.ts", ".generated.cs", "_string.go",
+	".designer.cs", ".g.dart", ".freezed.dart",
+}
+
+// sourceExtensions são as extensões consideradas código-fonte na busca por
+// pacotes e testes
+var sourceExtensions = map[string]bool{
+	".go": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".mjs": true,
+	".py": true, ".rb": true, ".java": true, ".kt": true, ".scala": true, ".cs": true,
+	".rs": true, ".php": true, ".swift": true, ".c": true, ".cc": true, ".cpp": true,
+	".ex": true, ".exs": true, ".dart": true,
+}
+
+// testDirs são diretórios que contêm apenas testes
+var testDirs = map[string]bool{
+	"test": true, "tests": true, "__tests__": true, "spec": true, "testdata": true,
+}
+
+// ciConfigs associa arquivos e diretórios de configuração ao sistema de CI
+var ciConfigs = []struct {
+	system string
+	match  func(path string) bool
+}{
+	{"GitHub Actions", func(p string) bool {
+		return strings.HasPrefix(p, ".github/workflows/") && (strings.HasSuffix(p, ".yml") || strings.HasSuffix(p, ".yaml"))
+	}},
+	{"GitLab CI", func(p string) bool { return p == ".gitlab-ci.yml" }},
+	{"CircleCI", func(p string) bool { return strings.HasPrefix(p, ".circleci/") }},
+	{"Travis CI", func(p string) bool { return p == ".travis.yml" }},
+	{"Jenkins", func(p string) bool { return path.Base(p) == "Jenkinsfile" }},
+	{"Azure Pipelines", func(p string) bool { return p == "azure-pipelines.yml" || strings.HasPrefix(p, ".azure-pipelines/") }},
+	{"Buildkite", func(p string) bool { return strings.HasPrefix(p, ".buildkite/") }},
+	{"Drone", func(p string) bool { return p == ".drone.yml" }},
+	{"Bitbucket Pipelines", func(p string) bool { return p == "bitbucket-pipelines.yml" }},
+	{"AppVeyor", func(p string) bool { return p == "appveyor.yml" || p == ".appveyor.yml" }},
+	{"Google Cloud Build", func(p string) bool { return p == "cloudbuild.yaml" || p == "cloudbuild.yml" }},
+}
+
+// TreeComposition resume a árvore de arquivos do branch padrão
+type TreeComposition struct {
+	Files     int  `json:"files"`
+	TotalSize int  `json:"total_size"`
+	Truncated bool `json:"truncated"`
+	Walked    bool `json:"walked"`
+
+	Extensions   []*PathStats          `json:"extensions"`
+	Directories  []*PathStats          `json:"directories"`
+	LargestFiles []*extractor.TreeFile `json:"largest_files"`
+
+	// Código de terceiros ou gerado
+	Vendored      []*PathStats `json:"vendored"`
+	VendoredFiles int          `json:"vendored_files"`
+
+	// Testes por pacote (diretórios com código-fonte, fora de vendor)
+	Packages          int      `json:"packages"`
+	PackagesWithTests int      `json:"packages_with_tests"`
+	TestFiles         int      `json:"test_files"`
+	UntestedPackages  []string `json:"untested_packages,omitempty"`
+
+	// Infraestrutura
+	Dockerfiles []string    `json:"dockerfiles,omitempty"`
+	CIConfigs   []*CIConfig `json:"ci_configs,omitempty"`
+}
+
+// PathStats conta arquivos e bytes de uma extensão ou diretório
+type PathStats struct {
+	Name  string `json:"name"`
+	Files int    `json:"files"`
+	Size  int    `json:"size"`
+}
+
+// CIConfig é um arquivo de configuração de integração contínua
+type CIConfig struct {
+	System string `json:"system"`
+	Path   string `json:"path"`
+}
+
+// AnalyzeTree calcula a composição do repositório a partir da árvore de
+// arquivos; retorna nil quando a árvore não foi extraída
+func AnalyzeTree(data *extractor.RepositoryData) *TreeComposition {
+	tree := data.Tree
+	if tree == nil {
+		return nil
+	}
+
+	composition := &TreeComposition{
+		Files:     len(tree.Files),
+		Truncated: tree.Truncated,
+		Walked:    tree.Walked,
+	}
+
+	extensions := make(map[string]*PathStats)
+	directories := make(map[string]*PathStats)
+	vendored := make(map[string]*PathStats)
+	packages := make(map[string]bool) // diretório -> possui testes
+
+	for _, file := range tree.Files {
+		composition.TotalSize += file.Size
+		addPathStats(extensions, fileExtension(file.Path), file.Size)
+		addPathStats(directories, topLevelDir(file.Path), file.Size)
+
+		if dir := vendoredDir(file.Path); dir != "" {
+			composition.VendoredFiles++
+			addPathStats(vendored, dir, file.Size)
+			continue
+		}
+
+		if isDockerFile(file.Path) {
+			composition.Dockerfiles = append(composition.Dockerfiles, file.Path)
+		}
+		for _, ci := range ciConfigs {
+			if ci.match(file.Path) {
+				composition.CIConfigs = append(composition.CIConfigs, &CIConfig{System: ci.system, Path: file.Path})
+				break
+			}
+		}
+
+		if !sourceExtensions[path.Ext(file.Path)] {
+			continue
+		}
+		dir := path.Dir(file.Path)
+		if testPackage, inTestDir := testDirPackage(dir); inTestDir || isTestFile(file.Path) {
+			composition.TestFiles++
+			if inTestDir {
+				// Testes em test/, tests/ ou __tests__ cobrem o pacote pai; na
+				// raiz, formam uma raiz de testes própria, sem pacote
+				if testPackage != "" {
+					packages[testPackage] = true
+				}
+				continue
+			}
+			packages[dir] = true
+			continue
+		}
+		if _, ok := packages[dir]; !ok {
+			packages[dir] = false
+		}
+	}
+
+	composition.Extensions = topPathStats(extensions, treeTopEntries)
+	composition.Directories = topPathStats(directories, treeTopEntries)
+	composition.Vendored = topPathStats(vendored, treeTopEntries)
+
+	for dir, tested := range packages {
+		composition.Packages++
+		if tested {
+			composition.PackagesWithTests++
+		} else {
+			composition.UntestedPackages = append(composition.UntestedPackages, dir)
+		}
+	}
+	sort.Strings(composition.UntestedPackages)
+	if len(composition.UntestedPackages) > treeUntestedSample {
+		composition.UntestedPackages = composition.UntestedPackages[:treeUntestedSample]
+	}
+
+	largest := make([]*extractor.TreeFile, len(tree.Files))
+	copy(largest, tree.Files)
+	sort.SliceStable(largest, func(i, j int) bool { return largest[i].Size > largest[j].Size })
+	if len(largest) > treeLargestFiles {
+		largest = largest[:treeLargestFiles]
+	}
+	composition.LargestFiles = largest
+
+	return composition
+}
+
+// addPathStats soma um arquivo à entrada do mapa
+func addPathStats(stats map[string]*PathStats, name string, size int) {
+	entry, ok := stats[name]
+	if !ok {
+		entry = &PathStats{Name: name}
+		stats[name] = entry
+	}
+	entry.Files++
+	entry.Size += size
+}
+
+// topPathStats ordena as entradas por número de arquivos e mantém as primeiras
+func topPathStats(stats map[string]*PathStats, limit int) []*PathStats {
+	list := make([]*PathStats, 0, len(stats))
+	for _, entry := range stats {
+		list = append(list, entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Files != list[j].Files {
+			return list[i].Files > list[j].Files
+		}
+		return list[i].Name < list[j].Name
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// fileExtension retorna a extensão em minúsculas; arquivos sem extensão
+// (Makefile, LICENSE) e dotfiles (.gitignore) são agrupados pelo nome
+func fileExtension(filePath string) string {
+	name := path.Base(filePath)
+	ext := strings.ToLower(path.Ext(name))
+	if ext == "" || ext == strings.ToLower(name) {
+		return name
+	}
+	return ext
+}
+
+// vendoredDir retorna o diretório de terceiros/gerado que contém o arquivo,
+// ou "(gerados)" para arquivos gerados reconhecidos pelo nome
+func vendoredDir(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments[:len(segments)-1] {
+		if vendoredDirs[segment] {
+			return strings.Join(segments[:i+1], "/") + "/"
+		}
+	}
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(filePath, suffix) {
+			return "(gerados)"
+		}
+	}
+	return ""
+}
+
+// testDirPackage indica se o diretório está dentro de um diretório de testes
+// e retorna o pacote coberto (ex: "pkg/tests/unit" -> "pkg"). Diretórios de
+// testes na raiz (ex: "tests") não cobrem um pacote específico: o pacote
+// retornado é vazio.
+func testDirPackage(dir string) (string, bool) {
+	segments := strings.Split(dir, "/")
+	for i, segment := range segments {
+		if testDirs[segment] {
+			return strings.Join(segments[:i], "/"), true
+		}
+	}
+	return "", false
+}
+
+// isTestFile reconhece as convenções de nome de testes das principais linguagens
+func isTestFile(filePath string) bool {
+	name := path.Base(filePath)
+	stem := strings.TrimSuffix(name, path.Ext(name))
+	switch {
+	case strings.HasSuffix(name, "_test.go"),
+		strings.HasSuffix(stem, ".test"), strings.HasSuffix(stem, ".spec"),
+		strings.HasPrefix(name, "test_") && strings.HasSuffix(name, ".py"),
+		strings.HasSuffix(stem, "_test"), strings.HasSuffix(stem, "_spec"),
+		strings.HasSuffix(stem, "Test"), strings.HasSuffix(stem, "Tests"):
+		return true
+	}
+	return false
+}
+
+// isDockerFile reconhece Dockerfiles e arquivos do Docker Compose
+func isDockerFile(filePath string) bool {
+	name := strings.ToLower(path.Base(filePath))
+	return name == "dockerfile" || strings.HasPrefix(name, "dockerfile.") || strings.HasSuffix(name, ".dockerfile") ||
+		strings.HasPrefix(name, "docker-compose") || name == "compose.yaml" || name == "compose.yml"
+}
+
+// TestedShare retorna a fração de pacotes com testes
+func (t *TreeComposition) TestedShare() float64 {
+	if t.Packages == 0 {
+		return 0
+	}
+	return float64(t.PackagesWithTests) / float64(t.Packages)
+}
+
+// ciSystems lista os sistemas de CI encontrados, sem repetição
+func (t *TreeComposition) ciSystems() []string {
+	var systems []string
+	seen := make(map[string]bool)
+	for _, ci := range t.CIConfigs {
+		if !seen[ci.System] {
+			seen[ci.System] = true
+			systems = append(systems, ci.System)
+		}
+	}
+	return systems
+}
+
+// summary resume a composição em uma linha
+func (t *TreeComposition) summary() string {
+	text := fmt.Sprintf("%s arquivos · %s", formatNumber(t.Files), formatSize(t.TotalSize))
+	if t.VendoredFiles > 0 {
+		text += fmt.Sprintf(" · %s de terceiros/gerados", formatNumber(t.VendoredFiles))
+	}
+	switch {
+	case t.Truncated:
+		text += " · árvore incompleta, valores parciais"
+	case t.Walked:
+		text += " · árvore remontada por subárvores"
+	}
+	return text
+}
+
+// testsText resume a presença de testes por pacote
+func (t *TreeComposition) testsText() string {
+	if t.Packages == 0 {
+		return "nenhum pacote com código-fonte"
+	}
+	return fmt.Sprintf("%d de %d pacotes com testes (%.1f%%) · %d arquivos de teste",
+		t.PackagesWithTests, t.Packages, t.TestedShare()*100, t.TestFiles)
+}
+
+// infraText resume Dockerfiles e CI
+func (t *TreeComposition) infraText() string {
+	ci := "nenhum"
+	if systems := t.ciSystems(); len(systems) > 0 {
+		ci = strings.Join(systems, ", ")
+	}
+	return fmt.Sprintf("CI: %s · Dockerfiles/Compose: %d", ci, len(t.Dockerfiles))
+}
+
+// pathStatsText formata uma lista de extensões ou diretórios
+func pathStatsText(stats []*PathStats) string {
+	parts := make([]string, len(stats))
+	for i, entry := range stats {
+		parts[i] = fmt.Sprintf("%s (%d)", entry.Name, entry.Files)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSize formata bytes em B, KB, MB ou GB
+func formatSize(bytes int) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes), "B"
+	for _, next := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value /= unit
+		suffix = next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+func (t *TreeComposition) sectionTitle() string { return "🌳 Composição do repositório" }
+
+// sectionLines resume extensões, diretórios, código gerado e testes
+func (t *TreeComposition) sectionLines() [][2]string {
+	lines := [][2]string{
+		{"Resumo", t.summary()},
+		{"Extensões", pathStatsText(t.Extensions)},
+		{"Diretórios", pathStatsText(t.Directories)},
+	}
+	if len(t.Vendored) > 0 {
+		lines = append(lines, [2]string{"Terceiros/gerados", pathStatsText(t.Vendored)})
+	}
+	lines = append(lines, [2]string{"Testes", t.testsText()})
+	if len(t.UntestedPackages) > 0 {
+		lines = append(lines, [2]string{"Pacotes sem testes", strings.Join(t.UntestedPackages, ", ")})
+	}
+	lines = append(lines, [2]string{"Infraestrutura", t.infraText()})
+	if len(t.LargestFiles) > 0 {
+		parts := make([]string, len(t.LargestFiles))
+		for i, file := range t.LargestFiles {
+			parts[i] = fmt.Sprintf("%s (%s)", file.Path, formatSize(file.Size))
+		}
+		lines = append(lines, [2]string{"Maiores arquivos", strings.Join(parts, ", ")})
+	}
+	return lines
+}
+
+// htmlChart mostra os arquivos por extensão
+func (t *TreeComposition) htmlChart() string {
+	var items []chartItem
+	for _, ext := range t.Extensions {
+		items = append(items, chartItem{Label: ext.Name, Value: float64(ext.Files)})
+	}
+	return barChartSVG(items)
+}
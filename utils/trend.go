@@ -0,0 +1,280 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github-octokit-poc/extractor"
+)
+
+const (
+	// trendWindowWeeks compara as últimas semanas com o mesmo número de
+	// semanas imediatamente anteriores
+	trendWindowWeeks = 4
+	// trendStableChange é a variação percentual abaixo da qual a tendência é estável
+	trendStableChange = 10.0
+)
+
+// Direções da tendência de commits
+const (
+	TrendUp     = "alta"
+	TrendDown   = "queda"
+	TrendStable = "estável"
+)
+
+// weekdayNames traduz os dias da API de estatísticas (0 = domingo)
+var weekdayNames = []string{"Domingo", "Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado"}
+
+// StatsAnalysis resume os endpoints de estatísticas do repositório: tendência
+// semanal de commits, volume de código, participação do dono e horários de pico
+type StatsAnalysis struct {
+	// Tendência (commit activity, últimas 52 semanas)
+	Weeks           []*WeeklyCommits `json:"weeks"`
+	CommitsLastYear int              `json:"commits_last_year"`
+	AvgPerWeek      float64          `json:"avg_commits_per_week"`
+	Slope           float64          `json:"slope_commits_per_week"`
+	RecentCommits   int              `json:"recent_commits"`
+	PreviousCommits int              `json:"previous_commits"`
+	TrendPercent    float64          `json:"trend_percent"`
+	Direction       string           `json:"direction"`
+
+	// Volume de código (code frequency, últimas 52 semanas)
+	AdditionsLastYear int `json:"additions_last_year"`
+	DeletionsLastYear int `json:"deletions_last_year"`
+
+	// Participação do dono (participation)
+	OwnerShare float64 `json:"owner_share"`
+
+	// Horários de pico (punch card, histórico completo)
+	DayTotals   []int  `json:"day_totals"`
+	HourTotals  []int  `json:"hour_totals"`
+	BusiestDay  string `json:"busiest_day"`
+	BusiestHour int    `json:"busiest_hour"`
+
+	// Pending lista os endpoints que o GitHub ainda estava calculando na
+	// extração; os indicadores que dependem deles ficam ausentes
+	Pending []string `json:"pending,omitempty"`
+}
+
+// WeeklyCommits representa os commits de uma semana
+type WeeklyCommits struct {
+	Week    time.Time `json:"week"`
+	Commits int       `json:"commits"`
+}
+
+// AnalyzeStats calcula tendência, volume e horários de pico a partir das
+// estatísticas extraídas; retorna nil quando os endpoints não foram consultados
+// e, se todos ainda estavam sendo calculados, apenas a lista de pendentes
+func AnalyzeStats(data *extractor.RepositoryData) *StatsAnalysis {
+	stats := data.Stats
+	if stats == nil {
+		if len(data.StatsPending) == 0 {
+			return nil
+		}
+		return &StatsAnalysis{BusiestHour: -1, Direction: TrendStable, Pending: data.StatsPending}
+	}
+
+	analysis := &StatsAnalysis{BusiestHour: -1, Pending: data.StatsPending}
+	analyzeCommitTrend(analysis, stats.CommitActivity, time.Now())
+
+	yearAgo := time.Now().AddDate(0, 0, -7*52)
+	for _, week := range stats.CodeFrequency {
+		if week.Week.Before(yearAgo) {
+			continue
+		}
+		analysis.AdditionsLastYear += week.Additions
+		analysis.DeletionsLastYear += week.Deletions
+	}
+
+	if participation := stats.Participation; participation != nil {
+		all, owner := sum(participation.All), sum(participation.Owner)
+		if all > 0 {
+			analysis.OwnerShare = float64(owner) / float64(all)
+		}
+	}
+
+	if len(stats.PunchCard) > 0 {
+		analysis.DayTotals = make([]int, 7)
+		analysis.HourTotals = make([]int, 24)
+		for _, entry := range stats.PunchCard {
+			if entry.Day < 0 || entry.Day > 6 || entry.Hour < 0 || entry.Hour > 23 {
+				continue
+			}
+			analysis.DayTotals[entry.Day] += entry.Commits
+			analysis.HourTotals[entry.Hour] += entry.Commits
+		}
+		if day := argmax(analysis.DayTotals); day >= 0 {
+			analysis.BusiestDay = weekdayNames[day]
+		}
+		analysis.BusiestHour = argmax(analysis.HourTotals)
+	}
+
+	return analysis
+}
+
+// analyzeCommitTrend calcula média, inclinação (regressão linear em
+// commits/semana) e a variação das últimas semanas sobre as anteriores. A
+// semana em andamento entra no total, mas não na média, na inclinação nem na
+// janela da tendência, pois está incompleta e puxaria a variação para baixo.
+func analyzeCommitTrend(analysis *StatsAnalysis, activity []*extractor.WeeklyCommitActivity, now time.Time) {
+	for _, week := range activity {
+		analysis.Weeks = append(analysis.Weeks, &WeeklyCommits{Week: week.Week, Commits: week.Total})
+		analysis.CommitsLastYear += week.Total
+	}
+
+	// A API ordena as semanas da mais antiga para a mais recente
+	for len(activity) > 0 && activity[len(activity)-1].Week.AddDate(0, 0, 7).After(now) {
+		activity = activity[:len(activity)-1]
+	}
+	n := len(activity)
+	if n == 0 {
+		analysis.Direction = TrendStable
+		return
+	}
+
+	complete := 0
+	for _, week := range activity {
+		complete += week.Total
+	}
+	analysis.AvgPerWeek = float64(complete) / float64(n)
+
+	// Regressão linear: x = índice da semana, y = commits
+	var sumX, sumY, sumXY, sumXX float64
+	for i, week := range activity {
+		x, y := float64(i), float64(week.Total)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	if denominator := float64(n)*sumXX - sumX*sumX; denominator != 0 {
+		analysis.Slope = (float64(n)*sumXY - sumX*sumY) / denominator
+	}
+
+	for i := n - 1; i >= 0 && i >= n-2*trendWindowWeeks; i-- {
+		if i >= n-trendWindowWeeks {
+			analysis.RecentCommits += activity[i].Total
+		} else {
+			analysis.PreviousCommits += activity[i].Total
+		}
+	}
+
+	switch {
+	case analysis.PreviousCommits > 0:
+		analysis.TrendPercent = float64(analysis.RecentCommits-analysis.PreviousCommits) /
+			float64(analysis.PreviousCommits) * 100
+	case analysis.RecentCommits > 0:
+		analysis.TrendPercent = 100
+	}
+
+	switch {
+	case analysis.TrendPercent >= trendStableChange:
+		analysis.Direction = TrendUp
+	case analysis.TrendPercent <= -trendStableChange:
+		analysis.Direction = TrendDown
+	default:
+		analysis.Direction = TrendStable
+	}
+}
+
+// busiestHourText formata a hora de pico (ex: "14h-15h")
+func (s *StatsAnalysis) busiestHourText() string {
+	if s.BusiestHour < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%02dh-%02dh", s.BusiestHour, (s.BusiestHour+1)%24)
+}
+
+// trendText resume a tendência em uma linha
+func (s *StatsAnalysis) trendText() string {
+	return fmt.Sprintf("%s (%+.0f%%: %d commits nas últimas %d semanas completas vs. %d nas %d anteriores)",
+		s.Direction, s.TrendPercent, s.RecentCommits, trendWindowWeeks, s.PreviousCommits, trendWindowWeeks)
+}
+
+// argmax retorna o índice do maior valor positivo, ou -1 se todos forem zero
+func argmax(values []int) int {
+	best := -1
+	for i, value := range values {
+		if value > 0 && (best < 0 || value > values[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (s *StatsAnalysis) sectionTitle() string { return "📆 Tendência de commits" }
+
+// sectionLines resume a tendência, o volume de código e os horários, com os
+// endpoints ainda pendentes
+func (s *StatsAnalysis) sectionLines() [][2]string {
+	var lines [][2]string
+	if len(s.Weeks) > 0 {
+		lines = append(lines,
+			[2]string{"Commits no último ano", fmt.Sprintf("%d (média de %.1f/semana)", s.CommitsLastYear, s.AvgPerWeek)},
+			[2]string{"Tendência", s.trendText()},
+			[2]string{"Inclinação (52 semanas)", fmt.Sprintf("%+.2f commits/semana", s.Slope)},
+		)
+	}
+	if s.AdditionsLastYear > 0 || s.DeletionsLastYear > 0 {
+		lines = append(lines, [2]string{"Linhas no último ano",
+			fmt.Sprintf("+%d / -%d", s.AdditionsLastYear, s.DeletionsLastYear)})
+	}
+	if s.OwnerShare > 0 {
+		lines = append(lines, [2]string{"Commits do dono do repositório", fmt.Sprintf("%.1f%%", s.OwnerShare*100)})
+	}
+	if s.BusiestDay != "" {
+		lines = append(lines,
+			[2]string{"Dia mais ativo", s.BusiestDay},
+			[2]string{"Horário de pico", s.busiestHourText()},
+		)
+	}
+	if len(s.Pending) > 0 {
+		lines = append(lines, [2]string{"Pendentes", "ainda sendo calculadas pelo GitHub: " + strings.Join(s.Pending, ", ")})
+	}
+	return lines
+}
+
+// htmlChart mostra os commits das últimas 12 semanas
+func (s *StatsAnalysis) htmlChart() string {
+	if len(s.Weeks) == 0 {
+		return ""
+	}
+
+	var items []chartItem
+	for _, week := range s.Weeks[max(0, len(s.Weeks)-12):] {
+		items = append(items, chartItem{Label: week.Week.Format("02/01"), Value: float64(week.Commits)})
+	}
+	return barChartSVG(items)
+}
+
+// markdownDetails lista os commits por dia da semana
+func (s *StatsAnalysis) markdownDetails() string {
+	if len(s.DayTotals) != len(weekdayNames) {
+		return ""
+	}
+
+	var md strings.Builder
+	md.WriteString("| Dia | Commits |\n|---|---:|\n")
+	for day, total := range s.DayTotals {
+		md.WriteString(fmt.Sprintf("| %s | %d |\n", weekdayNames[day], total))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
+
+// htmlDetails mostra o gráfico de commits por dia da semana
+func (s *StatsAnalysis) htmlDetails() string {
+	if len(s.DayTotals) != len(weekdayNames) {
+		return ""
+	}
+
+	var items []chartItem
+	for day, total := range s.DayTotals {
+		items = append(items, chartItem{Label: string([]rune(weekdayNames[day])[:3]), Value: float64(total)})
+	}
+	return "<h3>🕒 Commits por dia da semana</h3>" + barChartSVG(items) +
+		fmt.Sprintf("<p>Dia mais ativo: <strong>%s</strong> · Horário de pico: <strong>%s</strong></p>",
+			html.EscapeString(s.BusiestDay), s.busiestHourText())
+}